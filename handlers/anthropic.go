@@ -0,0 +1,499 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	openai "github.com/sashabaranov/go-openai"
+
+	"github.com/pocketbase/pocketbase/models"
+)
+
+const (
+	anthropicDefaultUrl = "https://api.anthropic.com/v1"
+	anthropicVersion    = "2023-06-01"
+	// the messages API requires max_tokens, used when the thread doesn't set one
+	anthropicDefaultMaxTokens = 4096
+)
+
+// Claude models through the native messages API
+type anthropicProvider struct {
+	apiRecord *models.Record
+}
+
+func newAnthropicProvider(apiRecord *models.Record) Provider {
+	return &anthropicProvider{apiRecord: apiRecord}
+}
+
+type anthropicImageSource struct {
+	Type      string `json:"type"`
+	MediaType string `json:"media_type,omitempty"`
+	Data      string `json:"data,omitempty"`
+	Url       string `json:"url,omitempty"`
+}
+
+// one content block, which fields are set depends on the type
+type anthropicContent struct {
+	Type string `json:"type"`
+	Text string `json:"text,omitempty"`
+	// images
+	Source *anthropicImageSource `json:"source,omitempty"`
+	// tool_use
+	Id    string          `json:"id,omitempty"`
+	Name  string          `json:"name,omitempty"`
+	Input json.RawMessage `json:"input,omitempty"`
+	// tool_result
+	ToolUseId string `json:"tool_use_id,omitempty"`
+	Content   string `json:"content,omitempty"`
+}
+
+type anthropicMessage struct {
+	Role    string             `json:"role"`
+	Content []anthropicContent `json:"content"`
+}
+
+type anthropicTool struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	InputSchema any    `json:"input_schema"`
+}
+
+type anthropicRequest struct {
+	Model         string             `json:"model"`
+	System        string             `json:"system,omitempty"`
+	Messages      []anthropicMessage `json:"messages"`
+	MaxTokens     int                `json:"max_tokens"`
+	Temperature   *float64           `json:"temperature,omitempty"`
+	TopP          *float64           `json:"top_p,omitempty"`
+	StopSequences []string           `json:"stop_sequences,omitempty"`
+	Tools         []anthropicTool    `json:"tools,omitempty"`
+	Stream        bool               `json:"stream,omitempty"`
+}
+
+type anthropicUsage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+}
+
+type anthropicResponse struct {
+	Id         string             `json:"id"`
+	Model      string             `json:"model"`
+	Content    []anthropicContent `json:"content"`
+	StopReason string             `json:"stop_reason"`
+	Usage      anthropicUsage     `json:"usage"`
+}
+
+type anthropicError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+func (p *anthropicProvider) url(path string) string {
+	base := strings.TrimSuffix(p.apiRecord.GetString("url"), "/")
+	if base == "" {
+		base = anthropicDefaultUrl
+	}
+	return base + path
+}
+
+func (p *anthropicProvider) do(ctx context.Context, method string, path string, body any) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, p.url(path), reader)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("x-api-key", p.apiRecord.GetString("api_key"))
+	request.Header.Set("anthropic-version", anthropicVersion)
+	if body != nil {
+		request.Header.Set("content-type", "application/json")
+	}
+
+	client := &http.Client{Transport: &retryAfterTransport{base: http.DefaultTransport}}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		defer response.Body.Close()
+		return nil, readAnthropicError(response)
+	}
+	return response, nil
+}
+
+// errors come back as go-openai API errors so retries treat every backend alike
+func readAnthropicError(response *http.Response) error {
+	body, _ := io.ReadAll(response.Body)
+
+	var data struct {
+		Error anthropicError `json:"error"`
+	}
+	if err := json.Unmarshal(body, &data); err != nil || data.Error.Message == "" {
+		data.Error = anthropicError{Type: "http_error", Message: strings.TrimSpace(string(body))}
+	}
+	return &openai.APIError{
+		Code:           data.Error.Type,
+		Message:        data.Error.Message,
+		Type:           data.Error.Type,
+		HTTPStatusCode: response.StatusCode,
+	}
+}
+
+func (p *anthropicProvider) ListModels(ctx context.Context) ([]ProviderModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	response, err := p.do(ctx, http.MethodGet, "/models?limit=1000", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	var data struct {
+		Data []struct {
+			Id string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to read models endpoint response: %w", err)
+	}
+
+	var providerModels []ProviderModel
+	for _, model := range data.Data {
+		providerModels = append(providerModels, ProviderModel{Id: model.Id})
+	}
+	return providerModels, nil
+}
+
+func anthropicImage(url string) anthropicContent {
+	source := &anthropicImageSource{Type: "url", Url: url}
+	if header, data, ok := strings.Cut(strings.TrimPrefix(url, "data:"), ";base64,"); ok && strings.HasPrefix(url, "data:") {
+		source = &anthropicImageSource{Type: "base64", MediaType: header, Data: data}
+	}
+	return anthropicContent{Type: "image", Source: source}
+}
+
+// content blocks of a chat message
+func anthropicBlocks(message openai.ChatCompletionMessage) []anthropicContent {
+	if message.Role == openai.ChatMessageRoleTool {
+		return []anthropicContent{{Type: "tool_result", ToolUseId: message.ToolCallID, Content: message.Content}}
+	}
+
+	var blocks []anthropicContent
+	if message.Content != "" {
+		blocks = append(blocks, anthropicContent{Type: "text", Text: message.Content})
+	}
+	for _, part := range message.MultiContent {
+		if part.Type == openai.ChatMessagePartTypeText && part.Text != "" {
+			blocks = append(blocks, anthropicContent{Type: "text", Text: part.Text})
+		} else if part.ImageURL != nil {
+			blocks = append(blocks, anthropicImage(part.ImageURL.URL))
+		}
+	}
+	for _, call := range message.ToolCalls {
+		input := json.RawMessage(call.Function.Arguments)
+		if !json.Valid(input) {
+			input = json.RawMessage("{}")
+		}
+		blocks = append(blocks, anthropicContent{Type: "tool_use", Id: call.ID, Name: call.Function.Name, Input: input})
+	}
+	return blocks
+}
+
+// system messages become the system parameter, tool results are sent by the user,
+// and messages in a row from the same role are joined since roles have to alternate
+func anthropicRequestFrom(req openai.ChatCompletionRequest) anthropicRequest {
+	request := anthropicRequest{
+		Model:         req.Model,
+		MaxTokens:     req.MaxTokens,
+		Temperature:   requestFloat(req.Temperature),
+		TopP:          requestFloat(req.TopP),
+		StopSequences: req.Stop,
+		Stream:        req.Stream,
+	}
+	if request.MaxTokens <= 0 {
+		request.MaxTokens = anthropicDefaultMaxTokens
+	}
+
+	var system []string
+	for _, message := range req.Messages {
+		if message.Role == openai.ChatMessageRoleSystem {
+			system = append(system, messageText(message))
+			continue
+		}
+
+		role := "user"
+		if message.Role == openai.ChatMessageRoleAssistant {
+			role = "assistant"
+		}
+		blocks := anthropicBlocks(message)
+		if len(blocks) == 0 {
+			continue
+		}
+
+		if last := len(request.Messages) - 1; last >= 0 && request.Messages[last].Role == role {
+			request.Messages[last].Content = append(request.Messages[last].Content, blocks...)
+		} else {
+			request.Messages = append(request.Messages, anthropicMessage{Role: role, Content: blocks})
+		}
+	}
+	request.System = strings.Join(system, "\n\n")
+
+	for _, tool := range req.Tools {
+		if tool.Function == nil {
+			continue
+		}
+		request.Tools = append(request.Tools, anthropicTool{
+			Name:        tool.Function.Name,
+			Description: tool.Function.Description,
+			InputSchema: tool.Function.Parameters,
+		})
+	}
+	return request
+}
+
+func anthropicFinishReason(stopReason string) openai.FinishReason {
+	switch stopReason {
+	case "max_tokens":
+		return openai.FinishReasonLength
+	case "tool_use":
+		return openai.FinishReasonToolCalls
+	case "":
+		return ""
+	}
+	return openai.FinishReasonStop
+}
+
+func (p *anthropicProvider) Chat(ctx context.Context, req openai.ChatCompletionRequest) (openai.ChatCompletionResponse, error) {
+	request := anthropicRequestFrom(req)
+	request.Stream = false
+
+	response, err := p.do(ctx, http.MethodPost, "/messages", request)
+	if err != nil {
+		return openai.ChatCompletionResponse{}, err
+	}
+	defer response.Body.Close()
+
+	var data anthropicResponse
+	if err := json.NewDecoder(response.Body).Decode(&data); err != nil {
+		return openai.ChatCompletionResponse{}, fmt.Errorf("failed to read messages response: %w", err)
+	}
+
+	message := openai.ChatCompletionMessage{Role: openai.ChatMessageRoleAssistant}
+	for _, block := range data.Content {
+		switch block.Type {
+		case "text":
+			message.Content += block.Text
+		case "tool_use":
+			message.ToolCalls = append(message.ToolCalls, openai.ToolCall{
+				ID:       block.Id,
+				Type:     openai.ToolTypeFunction,
+				Function: openai.FunctionCall{Name: block.Name, Arguments: string(block.Input)},
+			})
+		}
+	}
+
+	return openai.ChatCompletionResponse{
+		ID:    data.Id,
+		Model: data.Model,
+		Choices: []openai.ChatCompletionChoice{{
+			Message:      message,
+			FinishReason: anthropicFinishReason(data.StopReason),
+		}},
+		Usage: openai.Usage{
+			PromptTokens:     data.Usage.InputTokens,
+			CompletionTokens: data.Usage.OutputTokens,
+			TotalTokens:      data.Usage.InputTokens + data.Usage.OutputTokens,
+		},
+	}, nil
+}
+
+func (p *anthropicProvider) StreamChat(ctx context.Context, req openai.ChatCompletionRequest) (ChatStream, error) {
+	request := anthropicRequestFrom(req)
+	request.Stream = true
+
+	response, err := p.do(ctx, http.MethodPost, "/messages", request)
+	if err != nil {
+		return nil, err
+	}
+	return &anthropicStream{body: response.Body, reader: bufio.NewReader(response.Body), toolIndex: make(map[int]int)}, nil
+}
+
+// reads the server-sent events of a messages stream as OpenAI chunks
+type anthropicStream struct {
+	body   io.ReadCloser
+	reader *bufio.Reader
+	usage  anthropicUsage
+	done   bool
+	// tool call index of each tool_use content block
+	toolIndex map[int]int
+	// thinking_delta of the last event
+	lastReasoning string
+}
+
+// one event of the stream, which fields are set depends on the type
+type anthropicEvent struct {
+	Type  string `json:"type"`
+	Index int    `json:"index"`
+	// message_start
+	Message struct {
+		Usage anthropicUsage `json:"usage"`
+	} `json:"message"`
+	// content_block_start
+	ContentBlock anthropicContent `json:"content_block"`
+	// content_block_delta and message_delta
+	Delta struct {
+		Type        string `json:"type"`
+		Text        string `json:"text"`
+		Thinking    string `json:"thinking"`
+		PartialJson string `json:"partial_json"`
+		StopReason  string `json:"stop_reason"`
+	} `json:"delta"`
+	Usage *anthropicUsage `json:"usage"`
+	Error anthropicError  `json:"error"`
+}
+
+// status codes of errors sent mid-stream, so the retryable ones are retried
+var anthropicErrorStatus = map[string]int{
+	"rate_limit_error":      http.StatusTooManyRequests,
+	"overloaded_error":      529,
+	"api_error":             http.StatusInternalServerError,
+	"invalid_request_error": http.StatusBadRequest,
+}
+
+// data of the next event, events are separated by blank lines
+func (s *anthropicStream) nextEvent() ([]byte, error) {
+	var data []byte
+	for {
+		line, err := s.reader.ReadBytes('\n')
+		line = bytes.TrimSpace(line)
+		if value, ok := bytes.CutPrefix(line, []byte("data:")); ok {
+			data = append(data, bytes.TrimSpace(value)...)
+		}
+		if len(line) == 0 && len(data) > 0 {
+			return data, nil
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) && len(data) > 0 {
+				return data, nil
+			}
+			if errors.Is(err, io.EOF) {
+				return nil, io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+	}
+}
+
+func (s *anthropicStream) Recv() (openai.ChatCompletionStreamResponse, error) {
+	s.lastReasoning = ""
+	for {
+		if s.done {
+			return openai.ChatCompletionStreamResponse{}, io.EOF
+		}
+
+		data, err := s.nextEvent()
+		if err != nil {
+			return openai.ChatCompletionStreamResponse{}, err
+		}
+		var event anthropicEvent
+		if err := json.Unmarshal(data, &event); err != nil {
+			return openai.ChatCompletionStreamResponse{}, fmt.Errorf("failed to read stream event: %w", err)
+		}
+
+		delta := openai.ChatCompletionStreamChoiceDelta{}
+		var finishReason openai.FinishReason
+		switch event.Type {
+		case "message_start":
+			s.usage = event.Message.Usage
+			continue
+		case "content_block_start":
+			if event.ContentBlock.Type != "tool_use" {
+				continue
+			}
+			index := len(s.toolIndex)
+			s.toolIndex[event.Index] = index
+			delta.ToolCalls = []openai.ToolCall{{
+				Index:    &index,
+				ID:       event.ContentBlock.Id,
+				Type:     openai.ToolTypeFunction,
+				Function: openai.FunctionCall{Name: event.ContentBlock.Name},
+			}}
+		case "content_block_delta":
+			switch event.Delta.Type {
+			case "text_delta":
+				delta.Content = event.Delta.Text
+			case "thinking_delta":
+				s.lastReasoning = event.Delta.Thinking
+			case "input_json_delta":
+				index := s.toolIndex[event.Index]
+				delta.ToolCalls = []openai.ToolCall{{
+					Index:    &index,
+					Function: openai.FunctionCall{Arguments: event.Delta.PartialJson},
+				}}
+			default:
+				continue
+			}
+		case "message_delta":
+			if event.Usage != nil {
+				s.usage.OutputTokens = event.Usage.OutputTokens
+			}
+			finishReason = anthropicFinishReason(event.Delta.StopReason)
+		case "message_stop":
+			s.done = true
+			continue
+		case "error":
+			status, ok := anthropicErrorStatus[event.Error.Type]
+			if !ok {
+				status = http.StatusInternalServerError
+			}
+			return openai.ChatCompletionStreamResponse{}, &openai.APIError{
+				Code:           event.Error.Type,
+				Message:        event.Error.Message,
+				Type:           event.Error.Type,
+				HTTPStatusCode: status,
+			}
+		default:
+			// ping and content_block_stop
+			continue
+		}
+
+		return openai.ChatCompletionStreamResponse{
+			Choices: []openai.ChatCompletionStreamChoice{{Delta: delta, FinishReason: finishReason}},
+		}, nil
+	}
+}
+
+func (s *anthropicStream) Reasoning() string {
+	return s.lastReasoning
+}
+
+func (s *anthropicStream) Close() error {
+	return s.body.Close()
+}
+
+func (s *anthropicStream) Usage() *openai.Usage {
+	if s.usage.InputTokens == 0 && s.usage.OutputTokens == 0 {
+		return nil
+	}
+	return &openai.Usage{
+		PromptTokens:     s.usage.InputTokens,
+		CompletionTokens: s.usage.OutputTokens,
+		TotalTokens:      s.usage.InputTokens + s.usage.OutputTokens,
+	}
+}
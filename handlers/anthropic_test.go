@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// a fake messages API, handle answers every request after the headers are checked
+func newAnthropicTestProvider(t *testing.T, handle func(w http.ResponseWriter, request anthropicRequest)) Provider {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/messages" {
+			t.Errorf("request sent to %s, want /v1/messages", r.URL.Path)
+		}
+		if key := r.Header.Get("x-api-key"); key != "sk-ant-test" {
+			t.Errorf("x-api-key = %q, want sk-ant-test", key)
+		}
+		if version := r.Header.Get("anthropic-version"); version != anthropicVersion {
+			t.Errorf("anthropic-version = %q, want %s", version, anthropicVersion)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			t.Errorf("Authorization header should not be sent, got %q", auth)
+		}
+
+		var request anthropicRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("failed to read request: %v", err)
+		}
+		handle(w, request)
+	}))
+	t.Cleanup(server.Close)
+
+	apiRecord := models.NewRecord(&models.Collection{Name: "apis"})
+	apiRecord.Set("url", server.URL+"/v1/")
+	apiRecord.Set("api_key", "sk-ant-test")
+	return newAnthropicProvider(apiRecord)
+}
+
+func anthropicTestRequest(stream bool) openai.ChatCompletionRequest {
+	return openai.ChatCompletionRequest{
+		Model: "claude-3-5-sonnet-latest",
+		Messages: []openai.ChatCompletionMessage{
+			{Role: openai.ChatMessageRoleSystem, Content: "be brief"},
+			{Role: openai.ChatMessageRoleSystem, Content: "answer in english"},
+			{Role: openai.ChatMessageRoleUser, Content: "what's the weather?"},
+		},
+		Stream: stream,
+	}
+}
+
+func TestAnthropicSystemPrompt(t *testing.T) {
+	provider := newAnthropicTestProvider(t, func(w http.ResponseWriter, request anthropicRequest) {
+		if request.System != "be brief\n\nanswer in english" {
+			t.Errorf("system = %q, want both system messages", request.System)
+		}
+		for _, message := range request.Messages {
+			if message.Role != "user" && message.Role != "assistant" {
+				t.Errorf("message with role %q should not be sent", message.Role)
+			}
+		}
+		if len(request.Messages) != 1 || request.Messages[0].Content[0].Text != "what's the weather?" {
+			t.Errorf("messages = %+v, want only the user message", request.Messages)
+		}
+		if request.MaxTokens != anthropicDefaultMaxTokens {
+			t.Errorf("max_tokens = %d, want %d", request.MaxTokens, anthropicDefaultMaxTokens)
+		}
+
+		io.WriteString(w, `{
+			"id": "msg_1",
+			"model": "claude-3-5-sonnet-latest",
+			"content": [{"type": "text", "text": "sunny"}],
+			"stop_reason": "end_turn",
+			"usage": {"input_tokens": 12, "output_tokens": 3}
+		}`)
+	})
+
+	response, err := provider.Chat(context.Background(), anthropicTestRequest(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content := response.Choices[0].Message.Content; content != "sunny" {
+		t.Errorf("content = %q, want sunny", content)
+	}
+	if reason := response.Choices[0].FinishReason; reason != openai.FinishReasonStop {
+		t.Errorf("finish reason = %q, want stop", reason)
+	}
+	if response.Usage.TotalTokens != 15 {
+		t.Errorf("usage = %+v, want 15 tokens", response.Usage)
+	}
+}
+
+func TestAnthropicStream(t *testing.T) {
+	provider := newAnthropicTestProvider(t, func(w http.ResponseWriter, request anthropicRequest) {
+		if !request.Stream {
+			t.Error("stream should be set")
+		}
+		w.Header().Set("content-type", "text/event-stream")
+		io.WriteString(w, `event: message_start
+data: {"type": "message_start", "message": {"usage": {"input_tokens": 20, "output_tokens": 1}}}
+
+event: content_block_start
+data: {"type": "content_block_start", "index": 0, "content_block": {"type": "text", "text": ""}}
+
+event: ping
+data: {"type": "ping"}
+
+event: content_block_delta
+data: {"type": "content_block_delta", "index": 0, "delta": {"type": "text_delta", "text": "Let me "}}
+
+event: content_block_delta
+data: {"type": "content_block_delta", "index": 0, "delta": {"type": "text_delta", "text": "check."}}
+
+event: content_block_stop
+data: {"type": "content_block_stop", "index": 0}
+
+event: content_block_start
+data: {"type": "content_block_start", "index": 1, "content_block": {"type": "tool_use", "id": "toolu_1", "name": "get_weather", "input": {}}}
+
+event: content_block_delta
+data: {"type": "content_block_delta", "index": 1, "delta": {"type": "input_json_delta", "partial_json": "{\"city\": "}}
+
+event: content_block_delta
+data: {"type": "content_block_delta", "index": 1, "delta": {"type": "input_json_delta", "partial_json": "\"Oslo\"}"}}
+
+event: content_block_stop
+data: {"type": "content_block_stop", "index": 1}
+
+event: message_delta
+data: {"type": "message_delta", "delta": {"stop_reason": "tool_use"}, "usage": {"output_tokens": 42}}
+
+event: message_stop
+data: {"type": "message_stop"}
+
+`)
+	})
+
+	stream, err := provider.StreamChat(context.Background(), anthropicTestRequest(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Close()
+
+	var content string
+	var toolCalls []openai.ToolCall
+	var finishReason openai.FinishReason
+	for {
+		response, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		choice := response.Choices[0]
+		content += choice.Delta.Content
+		for _, call := range choice.Delta.ToolCalls {
+			if call.Index == nil {
+				t.Fatal("tool call chunk without an index")
+			}
+			if *call.Index == len(toolCalls) {
+				toolCalls = append(toolCalls, call)
+			} else {
+				toolCalls[*call.Index].Function.Arguments += call.Function.Arguments
+			}
+		}
+		if choice.FinishReason != "" {
+			finishReason = choice.FinishReason
+		}
+	}
+
+	if content != "Let me check." {
+		t.Errorf("content = %q, want %q", content, "Let me check.")
+	}
+	if len(toolCalls) != 1 {
+		t.Fatalf("got %d tool calls, want 1", len(toolCalls))
+	}
+	call := toolCalls[0]
+	if call.ID != "toolu_1" || call.Function.Name != "get_weather" || call.Function.Arguments != `{"city": "Oslo"}` {
+		t.Errorf("tool call = %+v, want get_weather with the streamed arguments", call)
+	}
+	if finishReason != openai.FinishReasonToolCalls {
+		t.Errorf("finish reason = %q, want tool_calls", finishReason)
+	}
+	usage := stream.Usage()
+	if usage == nil || usage.PromptTokens != 20 || usage.CompletionTokens != 42 || usage.TotalTokens != 62 {
+		t.Errorf("usage = %+v, want 20 prompt and 42 completion tokens", usage)
+	}
+}
+
+func TestAnthropicFinishReason(t *testing.T) {
+	for stopReason, want := range map[string]openai.FinishReason{
+		"end_turn":      openai.FinishReasonStop,
+		"stop_sequence": openai.FinishReasonStop,
+		"max_tokens":    openai.FinishReasonLength,
+		"tool_use":      openai.FinishReasonToolCalls,
+		"":              "",
+	} {
+		if got := anthropicFinishReason(stopReason); got != want {
+			t.Errorf("anthropicFinishReason(%q) = %q, want %q", stopReason, got, want)
+		}
+	}
+}
+
+func TestAnthropicError(t *testing.T) {
+	provider := newAnthropicTestProvider(t, func(w http.ResponseWriter, request anthropicRequest) {
+		w.WriteHeader(http.StatusBadRequest)
+		io.WriteString(w, `{"type": "error", "error": {"type": "invalid_request_error", "message": "max_tokens: too large"}}`)
+	})
+
+	_, err := provider.StreamChat(context.Background(), anthropicTestRequest(true))
+	var apiError *openai.APIError
+	if !errors.As(err, &apiError) {
+		t.Fatalf("error = %v, want an openai.APIError", err)
+	}
+	if apiError.HTTPStatusCode != http.StatusBadRequest || apiError.Type != "invalid_request_error" || apiError.Message != "max_tokens: too large" {
+		t.Errorf("error = %+v, want the status, type and message of the response", apiError)
+	}
+	if retryableError(err) {
+		t.Error("an invalid request should not be retried")
+	}
+}
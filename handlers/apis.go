@@ -2,10 +2,12 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/erikmillergalow/htmx-llmchat/templates"
 
@@ -15,22 +17,6 @@ import (
 	"github.com/pocketbase/pocketbase/models"
 )
 
-// types for parsing API /models endpoint response
-type ApiNamesResponse struct {
-	Object string     `json:"object"`
-	Data   []ApiModel `json:"data"`
-}
-
-type ApiModel struct {
-	Id            string `json:"id"`
-	Object        string `json:"object"`
-	Created       int64  `json:"created"`
-	OwnedBy       string `json:"owned_by"`
-	Active        bool   `json:"active"`
-	ContextWindow int    `json:"context_window"`
-	PublicApps    any    `json:"public_apps"`
-}
-
 // populate the chat API select
 func LoadApis(c echo.Context, app *pocketbase.PocketBase) error {
 	var apiEditorParams []templates.ApiParams
@@ -68,39 +54,63 @@ func ModelsUnavailableResponse(c echo.Context) error {
 	return nil
 }
 
-// populate the chat model select
-func LoadApiModels(modelId string, c echo.Context, app *pocketbase.PocketBase) error {
-	apiRecord, err := app.Dao().FindRecordById("apis", modelId)
+// context windows reported by each API's /models endpoint, keyed by api id and model name
+var modelContextWindows sync.Map
+
+// list the models an API provides and remember their context windows
+func fetchApiModels(apiRecord *models.Record) ([]ProviderModel, error) {
+	provider, err := newProvider(apiRecord)
 	if err != nil {
-		return c.String(http.StatusInternalServerError, "failed to retrieve user record")
+		return nil, err
 	}
 
-	listModelsUrl := apiRecord.GetString("url") + "/models"
-	request, err := http.NewRequest("GET", listModelsUrl, nil)
-	request.Header.Add("Authorization", "Bearer "+apiRecord.GetString("api_key"))
-
-	client := &http.Client{}
-	response, err := client.Do(request)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	providerModels, err := provider.ListModels(ctx)
 	if err != nil {
-		return ModelsUnavailableResponse(c)
+		return nil, err
 	}
-	defer response.Body.Close()
 
-	body, err := io.ReadAll(response.Body)
-	if err != nil {
+	for _, model := range providerModels {
+		modelContextWindows.Store(apiRecord.Id+"/"+model.Id, model.ContextWindow)
+	}
+
+	return providerModels, nil
+}
+
+// context window of a model in tokens, 0 if neither the API nor its settings say
+func contextWindowLimit(apiRecord *models.Record, modelName string) int {
+	key := apiRecord.Id + "/" + modelName
+	if _, ok := modelContextWindows.Load(key); !ok {
+		// only ask once, unreachable APIs and unlisted models are remembered as unknown
+		if _, err := fetchApiModels(apiRecord); err != nil {
+			fmt.Printf("Failed to list models for context window: %v\n", err)
+		}
+		modelContextWindows.LoadOrStore(key, 0)
+	}
+
+	if contextWindow, _ := modelContextWindows.Load(key); contextWindow.(int) > 0 {
+		return contextWindow.(int)
+	}
+	return apiRecord.GetInt("context_window")
+}
 
-		return c.String(http.StatusInternalServerError, "failed to read models endpoint response")
+// populate the chat model select
+func LoadApiModels(modelId string, c echo.Context, app *pocketbase.PocketBase) error {
+	apiRecord, err := app.Dao().FindRecordById("apis", modelId)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to retrieve user record")
 	}
 
-	var data ApiNamesResponse
-	err = json.Unmarshal(body, &data)
+	apiModels, err := fetchApiModels(apiRecord)
 	if err != nil {
-		return c.String(http.StatusInternalServerError, "failed to read models endpoint response")
+		fmt.Printf("Failed to list models: %v\n", err)
+		return ModelsUnavailableResponse(c)
 	}
 
-	var modelNames []string
-	for _, model := range data.Data {
-		modelNames = append(modelNames, model.Id)
+	var modelOptions []templates.ModelOptionParams
+	for _, model := range apiModels {
+		modelOptions = append(modelOptions, templates.ModelOptionParams{Name: model.Id, Details: model.Details})
 	}
 
 	// set selected model in users table
@@ -112,7 +122,7 @@ func LoadApiModels(modelId string, c echo.Context, app *pocketbase.PocketBase) e
 	selectedModelName := userRecord.GetString("selected_model_name")
 
 	c.Response().Writer.WriteHeader(200)
-	modelNamesSelect := templates.ApiModelSelect(selectedModelName, modelNames)
+	modelNamesSelect := templates.ApiModelSelect(selectedModelName, modelOptions)
 	err = modelNamesSelect.Render(context.Background(), c.Response().Writer)
 	if err != nil {
 		return c.String(http.StatusInternalServerError, "failed to retrieve models from API")
@@ -132,7 +142,7 @@ func OpenApiEditor(c echo.Context, app *pocketbase.PocketBase) error {
 		All(&apiEditorParams)
 
 	c.Response().Writer.WriteHeader(200)
-	modelEditor := templates.ApiEditorsList(apiEditorParams)
+	modelEditor := templates.ApiEditorsList(apiEditorParams, apiTypeOptions(), promptFormatOptions())
 	err := modelEditor.Render(context.Background(), c.Response().Writer)
 	if err != nil {
 		return c.String(http.StatusInternalServerError, "failed to render model editor")
@@ -156,6 +166,7 @@ func CreateApi(c echo.Context, app *pocketbase.PocketBase) error {
 		"url":            "",
 		"api_key":        "",
 		"api_model_name": "",
+		"type":           providerTypes[0].Type,
 	})
 	if err != nil {
 		return c.String(http.StatusInternalServerError, "failed to create new api DB record")
@@ -184,10 +195,11 @@ func CreateApi(c echo.Context, app *pocketbase.PocketBase) error {
 		Name:         "",
 		Url:          "",
 		ApiKey:       "",
+		Type:         providerTypes[0].Type,
 	}
 
 	c.Response().Writer.WriteHeader(200)
-	newModel := templates.NewApiEditor(apiParams)
+	newModel := templates.NewApiEditor(apiParams, apiTypeOptions(), promptFormatOptions())
 	err = newModel.Render(context.Background(), c.Response().Writer)
 	if err != nil {
 		return c.String(http.StatusInternalServerError, "failed to render new api DB entry")
@@ -206,6 +218,43 @@ func UpdateApi(id string, data map[string]any, c echo.Context, app *pocketbase.P
 	apiRecord.Set("name", data["display-name"].(string))
 	apiRecord.Set("url", data["url"].(string))
 	apiRecord.Set("api_key", data["api-key"].(string))
+	if providerType, ok := findProviderType(FormValue(data, "api-type")); ok {
+		apiRecord.Set("type", providerType.Type)
+	}
+
+	// an empty format keeps the chat endpoint
+	promptFormat := FormValue(data, "prompt-format")
+	if _, ok := findPromptFormat(promptFormat); ok || promptFormat == "" {
+		apiRecord.Set("prompt_format", promptFormat)
+	}
+	// the custom format has nothing to fall back on
+	promptTemplate := FormValue(data, "prompt-template")
+	if promptFormat == "custom" && strings.TrimSpace(promptTemplate) == "" {
+		return c.String(http.StatusBadRequest, "the custom prompt format needs a template")
+	}
+	apiRecord.Set("prompt_template", promptTemplate)
+
+	// used when the API doesn't report a context window for its models
+	contextWindow, err := strconv.Atoi(FormValue(data, "context-window"))
+	if err != nil || contextWindow < 0 {
+		contextWindow = 0
+	}
+	apiRecord.Set("context_window", contextWindow)
+	apiRecord.Set("skip_stream_usage", data["skip-stream-usage"] != nil)
+	apiRecord.Set("template_opens_think", data["template-opens-think"] != nil)
+
+	// 0 leaves the API unlimited
+	maxConcurrent, err := strconv.Atoi(FormValue(data, "max-concurrent"))
+	if err != nil || maxConcurrent < 0 {
+		maxConcurrent = 0
+	}
+	apiRecord.Set("max_concurrent", maxConcurrent)
+
+	params, err := parseGenerationParams(data)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "failed to read default generation parameters: "+err.Error())
+	}
+	apiRecord.Set("generation_params", params)
 
 	// set user selected api endpoint
 	// set selected model in users table
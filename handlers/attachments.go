@@ -0,0 +1,328 @@
+package handlers
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/erikmillergalow/htmx-llmchat/templates"
+
+	"github.com/labstack/echo/v5"
+	openai "github.com/sashabaranov/go-openai"
+
+	"github.com/pocketbase/pocketbase"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/filesystem"
+)
+
+// extracted text past this many characters is cut off
+const maxAttachmentText = 100000
+
+// vision models bill a detailed image at roughly this many tokens
+const imageTokenEstimate = 765
+
+func loadAttachments(record *models.Record) templates.AttachmentList {
+	var attachments templates.AttachmentList
+	if err := attachments.Scan(record.GetString("attachment_meta")); err != nil {
+		fmt.Printf("Failed to read message attachments: %v\n", err)
+	}
+	return attachments
+}
+
+// work out what kind of file was uploaded and pull out the text a model can read
+func extractAttachment(name string, data []byte) templates.AttachmentParams {
+	attachment := templates.AttachmentParams{Name: name}
+
+	// the extension decides for text files, sniffing would call them all text/plain
+	attachment.Type = http.DetectContentType(data)
+	if byExtension := mime.TypeByExtension(strings.ToLower(filepath.Ext(name))); byExtension != "" && !strings.HasPrefix(attachment.Type, "image/") {
+		attachment.Type = byExtension
+	}
+
+	switch {
+	case strings.HasPrefix(attachment.Type, "image/"):
+		return attachment
+	case strings.HasPrefix(attachment.Type, "application/pdf"):
+		attachment.Text = extractPdfText(data)
+		if attachment.Text == "" {
+			attachment.Text = "[no text could be extracted from this PDF]"
+		}
+	case utf8.Valid(data) && !slices.Contains(data, 0):
+		attachment.Text = string(data)
+	default:
+		attachment.Text = "[binary file, contents not included]"
+	}
+
+	if utf8.RuneCountInString(attachment.Text) > maxAttachmentText {
+		attachment.Text = truncateMessage(attachment.Text, maxAttachmentText) + "\n[truncated]"
+	}
+
+	return attachment
+}
+
+func renderAttachmentDraft(draftRecord *models.Record, c echo.Context) error {
+	c.Response().Writer.WriteHeader(200)
+	draft := templates.AttachmentDraft(draftRecord.Id, loadAttachments(draftRecord))
+	err := draft.Render(context.Background(), c.Response().Writer)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to render attachments")
+	}
+
+	return nil
+}
+
+// files are uploaded to a draft message before it is sent, the draft is reused until then
+func UploadAttachments(draftId string, c echo.Context, app *pocketbase.PocketBase) error {
+	if err := c.Request().ParseMultipartForm(32 << 20); err != nil {
+		return c.String(http.StatusBadRequest, "failed to read uploaded files")
+	}
+	fileHeaders := c.Request().MultipartForm.File["attachments"]
+
+	var draftRecord *models.Record
+	if draftId != "" {
+		record, err := app.Dao().FindRecordById("chat", draftId)
+		if err == nil && record.GetBool("draft") {
+			draftRecord = record
+		}
+	}
+	if draftRecord == nil {
+		chatCollection, err := app.Dao().FindCollectionByNameOrId("chat")
+		if err != nil {
+			return c.String(http.StatusInternalServerError, "failed to read chat DB")
+		}
+		draftRecord = models.NewRecord(chatCollection)
+		draftRecord.Set("sender", "human")
+		draftRecord.Set("draft", true)
+	}
+
+	attachments := loadAttachments(draftRecord)
+	var storedFiles []*filesystem.File
+	for _, fileHeader := range fileHeaders {
+		file, err := fileHeader.Open()
+		if err != nil {
+			return c.String(http.StatusBadRequest, "failed to read uploaded file")
+		}
+		data, err := io.ReadAll(file)
+		file.Close()
+		if err != nil {
+			return c.String(http.StatusBadRequest, "failed to read uploaded file")
+		}
+
+		attachment := extractAttachment(fileHeader.Filename, data)
+		storedFile, err := filesystem.NewFileFromBytes(data, fileHeader.Filename)
+		if err != nil {
+			return c.String(http.StatusInternalServerError, "failed to store uploaded file")
+		}
+		attachment.File = storedFile.Name
+
+		storedFiles = append(storedFiles, storedFile)
+		attachments = append(attachments, attachment)
+	}
+
+	// loading data resets files added before it, so files go last
+	form := forms.NewRecordUpsert(app, draftRecord)
+	form.LoadData(map[string]any{
+		"attachment_meta": attachments,
+	})
+	if err := form.AddFiles("attachments", storedFiles...); err != nil {
+		return c.String(http.StatusInternalServerError, "failed to store uploaded file")
+	}
+	if err := form.Submit(); err != nil {
+		fmt.Printf("Failed to save attachments: %v\n", err)
+		return c.String(http.StatusBadRequest, "failed to save attachments, files are limited to 20MB and 10 per message")
+	}
+
+	return renderAttachmentDraft(draftRecord, c)
+}
+
+func RemoveAttachment(draftId string, file string, c echo.Context, app *pocketbase.PocketBase) error {
+	draftRecord, err := app.Dao().FindRecordById("chat", draftId)
+	if err != nil || !draftRecord.GetBool("draft") {
+		return c.String(http.StatusBadRequest, "attachments can only be removed before sending")
+	}
+
+	attachments := slices.DeleteFunc(loadAttachments(draftRecord), func(a templates.AttachmentParams) bool {
+		return a.File == file
+	})
+
+	form := forms.NewRecordUpsert(app, draftRecord)
+	form.LoadData(map[string]any{
+		"attachment_meta": attachments,
+	})
+	if err := form.RemoveFiles("attachments", file); err != nil {
+		return c.String(http.StatusInternalServerError, "failed to remove attachment")
+	}
+	if err := form.Submit(); err != nil {
+		return c.String(http.StatusInternalServerError, "failed to remove attachment")
+	}
+
+	return renderAttachmentDraft(draftRecord, c)
+}
+
+// chat records aren't exposed through the PocketBase API, so files are served here
+func ServeAttachment(messageId string, file string, c echo.Context, app *pocketbase.PocketBase) error {
+	messageRecord, err := app.Dao().FindRecordById("chat", messageId)
+	if err != nil || !slices.Contains(messageRecord.GetStringSlice("attachments"), file) {
+		return c.String(http.StatusNotFound, "attachment not found")
+	}
+
+	fs, err := app.NewFilesystem()
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to open file storage")
+	}
+	defer fs.Close()
+
+	if err := fs.Serve(c.Response(), c.Request(), messageRecord.BaseFilesPath()+"/"+file, file); err != nil {
+		return c.String(http.StatusNotFound, "attachment not found")
+	}
+
+	return nil
+}
+
+// store a human message, filling in the draft its attachments were uploaded to if there is one
+func saveHumanMessage(draftId string, data map[string]any, app *pocketbase.PocketBase) (*models.Record, error) {
+	var record *models.Record
+	if draftId != "" {
+		draftRecord, err := app.Dao().FindRecordById("chat", draftId)
+		if err != nil || !draftRecord.GetBool("draft") {
+			return nil, errors.New("the attachments were already sent or removed, attach them again")
+		}
+		record = draftRecord
+	} else {
+		chatCollection, err := app.Dao().FindCollectionByNameOrId("chat")
+		if err != nil {
+			return nil, err
+		}
+		record = models.NewRecord(chatCollection)
+	}
+
+	data["draft"] = false
+	form := forms.NewRecordUpsert(app, record)
+	form.LoadData(data)
+	if err := form.Submit(); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// copies of the attachments of a message to add to a new record, files are stored per record
+func copyAttachments(fromRecord *models.Record, app *pocketbase.PocketBase) (templates.AttachmentList, []*filesystem.File, error) {
+	attachments := loadAttachments(fromRecord)
+	if len(attachments) == 0 {
+		return nil, nil, nil
+	}
+
+	fs, err := app.NewFilesystem()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer fs.Close()
+
+	var storedFiles []*filesystem.File
+	for i, attachment := range attachments {
+		data, err := readAttachment(fs, fromRecord.BaseFilesPath(), attachment)
+		if err != nil {
+			return nil, nil, err
+		}
+		storedFile, err := filesystem.NewFileFromBytes(data, attachment.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+		storedFiles = append(storedFiles, storedFile)
+		attachments[i].File = storedFile.Name
+	}
+
+	return attachments, storedFiles, nil
+}
+
+func readAttachment(fs *filesystem.System, basePath string, attachment templates.AttachmentParams) ([]byte, error) {
+	reader, err := fs.GetFile(basePath + "/" + attachment.File)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open attachment %s: %w", attachment.Name, err)
+	}
+	defer reader.Close()
+
+	return io.ReadAll(reader)
+}
+
+// a human message with its attachments, text files are added to the message and images sent as image parts
+func humanContextMessage(message templates.LoadedMessageParams, app *pocketbase.PocketBase) (contextMessage, error) {
+	if len(message.Attachments) == 0 {
+		return newContextMessage(message.Id, openai.ChatMessageRoleUser, message.Message), nil
+	}
+
+	chatCollection, err := app.Dao().FindCollectionByNameOrId("chat")
+	if err != nil {
+		return contextMessage{}, err
+	}
+	basePath := chatCollection.BaseFilesPath() + "/" + message.Id
+
+	text := message.Message
+	var images []openai.ChatMessagePart
+	for _, attachment := range message.Attachments {
+		if !attachment.IsImage() {
+			text += "\n\nAttachment " + attachment.Name + ":\n```\n" + attachment.Text + "\n```"
+			continue
+		}
+
+		fs, err := app.NewFilesystem()
+		if err != nil {
+			return contextMessage{}, err
+		}
+		data, err := readAttachment(fs, basePath, attachment)
+		fs.Close()
+		if err != nil {
+			return contextMessage{}, err
+		}
+
+		images = append(images, openai.ChatMessagePart{
+			Type: openai.ChatMessagePartTypeImageURL,
+			ImageURL: &openai.ChatMessageImageURL{
+				URL: "data:" + attachment.Type + ";base64," + base64.StdEncoding.EncodeToString(data),
+			},
+		})
+	}
+
+	contextMessage := newContextMessage(message.Id, openai.ChatMessageRoleUser, text)
+	if len(images) == 0 {
+		return contextMessage, nil
+	}
+
+	// content and parts can't both be set
+	contextMessage.Message.Content = ""
+	contextMessage.Message.MultiContent = append([]openai.ChatMessagePart{
+		{Type: openai.ChatMessagePartTypeText, Text: text},
+	}, images...)
+	contextMessage.Tokens += len(images) * imageTokenEstimate
+
+	return contextMessage, nil
+}
+
+// the text of a message, including the text parts of one with images and the tools it called
+func messageText(message openai.ChatCompletionMessage) string {
+	var parts []string
+	if len(message.MultiContent) == 0 && message.Content != "" {
+		parts = append(parts, message.Content)
+	}
+	for _, part := range message.MultiContent {
+		if part.Type == openai.ChatMessagePartTypeText {
+			parts = append(parts, part.Text)
+		} else {
+			parts = append(parts, "[image]")
+		}
+	}
+	for _, call := range message.ToolCalls {
+		parts = append(parts, "[called "+call.Function.Name+" "+call.Function.Arguments+"]")
+	}
+	return strings.Join(parts, "\n")
+}
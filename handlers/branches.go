@@ -0,0 +1,280 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/erikmillergalow/htmx-llmchat/templates"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// messages of a thread linked by parent_id, editing or regenerating a message adds a sibling branch
+type messageTree struct {
+	messages map[string]templates.LoadedMessageParams
+	// child ids in creation order, root messages are under ""
+	children map[string][]string
+}
+
+func loadMessageTree(threadId string, app *pocketbase.PocketBase) (*messageTree, error) {
+	var messages []templates.LoadedMessageParams
+	err := app.Dao().DB().
+		Select("*").
+		From("chat").
+		Where(dbx.NewExp("thread_id = {:id}", dbx.Params{"id": threadId})).
+		OrderBy("created ASC", "rowid ASC").
+		All(&messages)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch thread messages: %w", err)
+	}
+
+	tree := &messageTree{
+		messages: make(map[string]templates.LoadedMessageParams),
+		children: make(map[string][]string),
+	}
+	for _, message := range messages {
+		tree.messages[message.Id] = message
+		tree.children[message.ParentId] = append(tree.children[message.ParentId], message.Id)
+	}
+
+	return tree, nil
+}
+
+// the branch the user last chose at a fork, falling back to the newest one
+func (t *messageTree) selectedChild(parentId string) (string, bool) {
+	children := t.children[parentId]
+	if len(children) == 0 {
+		return "", false
+	}
+
+	for i := len(children) - 1; i >= 0; i-- {
+		if !t.messages[children[i]].Inactive {
+			return children[i], true
+		}
+	}
+	return children[len(children)-1], true
+}
+
+// ids of the messages renderThread shows, as SQL for filtering searches
+// follows selectedChild at every fork, down from the roots of each thread, plus the other
+// answers of a compare prompt on that path, which are shown side by side
+const shownMessagesQuery = `WITH RECURSIVE active_path(id, compare) AS (
+		SELECT c.id, c.compare FROM chat c
+		WHERE c.parent_id = '' AND c.id = (` + selectedChildQuery + `)
+		UNION ALL
+		SELECT c.id, c.compare FROM chat c
+		INNER JOIN active_path a ON c.parent_id = a.id
+		WHERE c.id = (` + selectedChildQuery + `)
+	)
+	SELECT id FROM active_path
+	UNION
+	SELECT c.id FROM chat c
+	INNER JOIN active_path a ON c.parent_id = a.id
+	WHERE a.compare != '' AND c.sender = 'model'`
+
+// the sibling of c that selectedChild picks
+const selectedChildQuery = `SELECT s.id FROM chat s
+		WHERE s.thread_id = c.thread_id AND s.parent_id = c.parent_id
+		ORDER BY s.inactive ASC, s.created DESC, s.rowid DESC
+		LIMIT 1`
+
+// include the fork position so the branch switcher can be shown
+func (t *messageTree) withSiblings(messageId string) templates.LoadedMessageParams {
+	message := t.messages[messageId]
+	message.Siblings = siblingNav(t.children[message.ParentId], messageId)
+	return message
+}
+
+// follow the selected branch at every fork, starting from the given message
+func (t *messageTree) pathFrom(messageId string) []templates.LoadedMessageParams {
+	var path []templates.LoadedMessageParams
+	for messageId != "" {
+		message := t.withSiblings(messageId)
+		if parent, ok := t.messages[message.ParentId]; ok && parent.Compare != "" {
+			message.CompareState = parent.Compare
+			message.CompareAnswers = t.compareAnswers(parent.Id)
+		}
+		path = append(path, message)
+		messageId, _ = t.selectedChild(messageId)
+	}
+	return path
+}
+
+// the messages currently shown in the thread
+func (t *messageTree) activePath() []templates.LoadedMessageParams {
+	rootId, ok := t.selectedChild("")
+	if !ok {
+		return nil
+	}
+	return t.pathFrom(rootId)
+}
+
+// new messages continue from the end of the active path
+func (t *messageTree) activeLeafId() string {
+	path := t.activePath()
+	if len(path) == 0 {
+		return ""
+	}
+	return path[len(path)-1].Id
+}
+
+// the messages leading up to and including the given message
+func (t *messageTree) pathTo(messageId string) []templates.LoadedMessageParams {
+	var path []templates.LoadedMessageParams
+	for messageId != "" {
+		message, ok := t.messages[messageId]
+		if !ok {
+			break
+		}
+		path = append(path, message)
+		messageId = message.ParentId
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// build the arrows shown on a message with sibling branches
+func siblingNav(siblingIds []string, activeId string) templates.SiblingNavParams {
+	index := slices.Index(siblingIds, activeId)
+	nav := templates.SiblingNavParams{
+		Index: index,
+		Count: len(siblingIds),
+	}
+	if index > 0 {
+		nav.PrevId = siblingIds[index-1]
+	}
+	if index >= 0 && index < len(siblingIds)-1 {
+		nav.NextId = siblingIds[index+1]
+	}
+	return nav
+}
+
+// mark one branch at a fork as selected, deactivating its siblings
+func selectSibling(threadId string, parentId string, messageId string, app *pocketbase.PocketBase) (templates.SiblingNavParams, error) {
+	siblingRecords, err := app.Dao().FindRecordsByFilter(
+		"chat",
+		"thread_id = {:thread} && parent_id = {:parent}",
+		"created",
+		0,
+		0,
+		dbx.Params{"thread": threadId, "parent": parentId},
+	)
+	if err != nil {
+		return templates.SiblingNavParams{}, fmt.Errorf("failed to fetch sibling messages: %w", err)
+	}
+
+	var siblingIds []string
+	for _, record := range siblingRecords {
+		siblingIds = append(siblingIds, record.Id)
+
+		inactive := record.Id != messageId
+		if record.GetBool("inactive") == inactive {
+			continue
+		}
+		record.Set("inactive", inactive)
+		if err := app.Dao().SaveRecord(record); err != nil {
+			return templates.SiblingNavParams{}, fmt.Errorf("failed to update sibling message: %w", err)
+		}
+	}
+
+	return siblingNav(siblingIds, messageId), nil
+}
+
+// switch a fork to one of its other branches and show the resulting path
+func SelectSibling(messageId string, c echo.Context, app *pocketbase.PocketBase) error {
+	messageRecord, err := app.Dao().FindRecordById("chat", messageId)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to find sibling message")
+	}
+	threadId := messageRecord.GetString("thread_id")
+
+	_, err = selectSibling(threadId, messageRecord.GetString("parent_id"), messageId, app)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to select sibling message")
+	}
+
+	return renderThread(threadId, "", c, app)
+}
+
+// open an editor in place of a human message
+func EditMessage(messageId string, c echo.Context, app *pocketbase.PocketBase) error {
+	messageRecord, err := app.Dao().FindRecordById("chat", messageId)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to find message to edit")
+	}
+
+	messageParams := templates.LoadedMessageParams{
+		Id:          messageRecord.Id,
+		Message:     messageRecord.GetString("message"),
+		ThreadId:    messageRecord.GetString("thread_id"),
+		Attachments: loadAttachments(messageRecord),
+	}
+
+	c.Response().Writer.WriteHeader(200)
+	messageEditor := templates.HumanMessageEditor(messageParams)
+	err = messageEditor.Render(context.Background(), c.Response().Writer)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to render message editor")
+	}
+
+	return nil
+}
+
+// store an edited human message as a new branch next to the original, then request a response to it
+func SaveEditedMessage(messageId string, message string, c echo.Context, app *pocketbase.PocketBase) error {
+	originalRecord, err := app.Dao().FindRecordById("chat", messageId)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to find edited message")
+	}
+	threadId := originalRecord.GetString("thread_id")
+	parentId := originalRecord.GetString("parent_id")
+
+	if message == "" || message == originalRecord.GetString("message") {
+		return renderThread(threadId, "", c, app)
+	}
+
+	_, _, chatModelName, err := loadSelectedModel(app)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to fetch selected model")
+	}
+
+	chatCollection, err := app.Dao().FindCollectionByNameOrId("chat")
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to read chat DB")
+	}
+
+	// the edited branch keeps the attachments of the original
+	attachments, attachmentFiles, err := copyAttachments(originalRecord, app)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to copy attachments to edited message")
+	}
+
+	editedRecord := models.NewRecord(chatCollection)
+	form := forms.NewRecordUpsert(app, editedRecord)
+	form.LoadData(map[string]any{
+		"thread_id":       threadId,
+		"parent_id":       parentId,
+		"message":         message,
+		"sender":          "human",
+		"model":           chatModelName,
+		"attachment_meta": attachments,
+	})
+	if err := form.AddFiles("attachments", attachmentFiles...); err != nil {
+		return c.String(http.StatusInternalServerError, "failed to copy attachments to edited message")
+	}
+	if err := form.Submit(); err != nil {
+		return c.String(http.StatusInternalServerError, "failed to save edited message")
+	}
+
+	if _, err := selectSibling(threadId, parentId, editedRecord.Id, app); err != nil {
+		return c.String(http.StatusInternalServerError, "failed to select edited message")
+	}
+
+	return renderThread(threadId, editedRecord.Id, c, app)
+}
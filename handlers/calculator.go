@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// arithmetic for the calculator tool: + - * / % ^, parentheses, constants and a few functions
+type calculator struct {
+	input []rune
+	pos   int
+}
+
+var calculatorConstants = map[string]float64{
+	"pi": math.Pi,
+	"e":  math.E,
+}
+
+var calculatorFunctions = map[string]func(float64) float64{
+	"sqrt":  math.Sqrt,
+	"abs":   math.Abs,
+	"ln":    math.Log,
+	"log":   math.Log10,
+	"log2":  math.Log2,
+	"exp":   math.Exp,
+	"sin":   math.Sin,
+	"cos":   math.Cos,
+	"tan":   math.Tan,
+	"asin":  math.Asin,
+	"acos":  math.Acos,
+	"atan":  math.Atan,
+	"floor": math.Floor,
+	"ceil":  math.Ceil,
+	"round": math.Round,
+}
+
+func evaluateExpression(expression string) (float64, error) {
+	calc := &calculator{input: []rune(expression)}
+	result, err := calc.sum()
+	if err != nil {
+		return 0, err
+	}
+	calc.skipSpaces()
+	if calc.pos < len(calc.input) {
+		return 0, fmt.Errorf("unexpected %q at position %d", calc.input[calc.pos], calc.pos+1)
+	}
+	if math.IsNaN(result) || math.IsInf(result, 0) {
+		return 0, fmt.Errorf("result is not a finite number")
+	}
+	return result, nil
+}
+
+func (c *calculator) skipSpaces() {
+	for c.pos < len(c.input) && unicode.IsSpace(c.input[c.pos]) {
+		c.pos++
+	}
+}
+
+// next non space character, 0 at the end
+func (c *calculator) peek() rune {
+	c.skipSpaces()
+	if c.pos >= len(c.input) {
+		return 0
+	}
+	return c.input[c.pos]
+}
+
+func (c *calculator) sum() (float64, error) {
+	result, err := c.product()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		switch c.peek() {
+		case '+':
+			c.pos++
+			value, err := c.product()
+			if err != nil {
+				return 0, err
+			}
+			result += value
+		case '-':
+			c.pos++
+			value, err := c.product()
+			if err != nil {
+				return 0, err
+			}
+			result -= value
+		default:
+			return result, nil
+		}
+	}
+}
+
+func (c *calculator) product() (float64, error) {
+	result, err := c.unary()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		operator := c.peek()
+		if operator != '*' && operator != '/' && operator != '%' {
+			return result, nil
+		}
+		c.pos++
+		value, err := c.unary()
+		if err != nil {
+			return 0, err
+		}
+		switch operator {
+		case '*':
+			result *= value
+		case '/':
+			if value == 0 {
+				return 0, fmt.Errorf("division by zero")
+			}
+			result /= value
+		case '%':
+			if value == 0 {
+				return 0, fmt.Errorf("division by zero")
+			}
+			result = math.Mod(result, value)
+		}
+	}
+}
+
+func (c *calculator) unary() (float64, error) {
+	switch c.peek() {
+	case '-':
+		c.pos++
+		value, err := c.unary()
+		return -value, err
+	case '+':
+		c.pos++
+		return c.unary()
+	}
+	return c.power()
+}
+
+// ^ binds tighter than a leading minus and groups from the right
+func (c *calculator) power() (float64, error) {
+	base, err := c.operand()
+	if err != nil {
+		return 0, err
+	}
+	if c.peek() != '^' {
+		return base, nil
+	}
+	c.pos++
+	exponent, err := c.unary()
+	if err != nil {
+		return 0, err
+	}
+	return math.Pow(base, exponent), nil
+}
+
+func (c *calculator) operand() (float64, error) {
+	next := c.peek()
+	switch {
+	case next == '(':
+		c.pos++
+		value, err := c.sum()
+		if err != nil {
+			return 0, err
+		}
+		if c.peek() != ')' {
+			return 0, fmt.Errorf("missing closing parenthesis")
+		}
+		c.pos++
+		return value, nil
+	case unicode.IsDigit(next) || next == '.':
+		start := c.pos
+		for c.pos < len(c.input) && (unicode.IsDigit(c.input[c.pos]) || c.input[c.pos] == '.' || c.input[c.pos] == '_') {
+			c.pos++
+		}
+		// exponent notation like 1.5e3
+		if c.pos < len(c.input) && (c.input[c.pos] == 'e' || c.input[c.pos] == 'E') {
+			end := c.pos + 1
+			if end < len(c.input) && (c.input[end] == '+' || c.input[end] == '-') {
+				end++
+			}
+			if end < len(c.input) && unicode.IsDigit(c.input[end]) {
+				for end < len(c.input) && unicode.IsDigit(c.input[end]) {
+					end++
+				}
+				c.pos = end
+			}
+		}
+		number := strings.ReplaceAll(string(c.input[start:c.pos]), "_", "")
+		value, err := strconv.ParseFloat(number, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid number %q", number)
+		}
+		return value, nil
+	case unicode.IsLetter(next):
+		start := c.pos
+		for c.pos < len(c.input) && (unicode.IsLetter(c.input[c.pos]) || unicode.IsDigit(c.input[c.pos])) {
+			c.pos++
+		}
+		name := strings.ToLower(string(c.input[start:c.pos]))
+		if value, ok := calculatorConstants[name]; ok {
+			return value, nil
+		}
+		function, ok := calculatorFunctions[name]
+		if !ok {
+			return 0, fmt.Errorf("unknown name %q", name)
+		}
+		if c.peek() != '(' {
+			return 0, fmt.Errorf("%s needs an argument in parentheses", name)
+		}
+		argument, err := c.operand()
+		if err != nil {
+			return 0, err
+		}
+		return function(argument), nil
+	case next == 0:
+		return 0, fmt.Errorf("unexpected end of expression")
+	default:
+		return 0, fmt.Errorf("unexpected %q at position %d", next, c.pos+1)
+	}
+}
+
+func formatCalculatorResult(value float64) string {
+	return strconv.FormatFloat(value, 'g', 15, 64)
+}
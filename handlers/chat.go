@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"net/http"
+	"strconv"
 
 	"github.com/erikmillergalow/htmx-llmchat/templates"
 
@@ -61,3 +62,16 @@ func ToggleMessageUsefulness(messageId string, c echo.Context, app *pocketbase.P
 	return nil
 }
 
+
+// read a submitted form value as text, PocketBase turns numeric form values into numbers
+func FormValue(data map[string]any, key string) string {
+	switch value := data[key].(type) {
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(value)
+	}
+	return ""
+}
@@ -7,15 +7,20 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/erikmillergalow/htmx-llmchat/templates"
 
+	"github.com/a-h/templ"
 	"github.com/gorilla/websocket"
 	"github.com/labstack/echo/v5"
 	openai "github.com/sashabaranov/go-openai"
 
-	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase"
 	"github.com/pocketbase/pocketbase/forms"
 	"github.com/pocketbase/pocketbase/models"
@@ -23,56 +28,181 @@ import (
 )
 
 type HTMXSocketMsg struct {
-	Headers  map[string]string `json:"HEADERS"`
-	Msg      string            `json:"new-message"`
-	ThreadId string            `json:"thread-id-chat"`
+	Headers   map[string]string `json:"HEADERS"`
+	Msg       string            `json:"new-message"`
+	ThreadId  string            `json:"thread-id-chat"`
+	Action    string            `json:"action"`
+	MessageId string            `json:"message-id"`
+	// last stream frame the client received, sent when resuming
+	After string `json:"after"`
+	// set when the message goes to every compare model
+	Compare string `json:"compare"`
+	// draft message holding the files attached to a new message
+	AttachmentDraft string `json:"attachment-draft"`
+	// values for the variables of a /prompt, sent as prompt-var-<name>
+	PromptVariables map[string]string `json:"-"`
 }
 
-func handleChatError(err error, ws *websocket.Conn, threadId string, app *pocketbase.PocketBase) {
+// browsers answer pings on their own, a missing pong means the connection is gone
+const (
+	socketWriteWait  = 10 * time.Second
+	socketPongWait   = 60 * time.Second
+	socketPingPeriod = 25 * time.Second
+)
+
+// how often the text of a response is written to the DB while it streams
+const partialSaveInterval = 2 * time.Second
+
+// gorilla websockets support a single concurrent writer, all writes go through here
+type chatSocket struct {
+	ws      *websocket.Conn
+	writeMu sync.Mutex
+}
+
+func (s *chatSocket) writeComponent(component templ.Component) error {
+	var htmlBuf bytes.Buffer
+	if err := component.Render(context.Background(), &htmlBuf); err != nil {
+		return err
+	}
+	return s.writeMessage(htmlBuf.Bytes())
+}
+
+func (s *chatSocket) writeMessage(data []byte) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	s.ws.SetWriteDeadline(time.Now().Add(socketWriteWait))
+	return s.ws.WriteMessage(websocket.TextMessage, data)
+}
+
+// ping until done is closed, WriteControl is safe alongside the other writes
+func (s *chatSocket) keepAlive(done chan struct{}) {
+	ticker := time.NewTicker(socketPingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if err := s.ws.WriteControl(websocket.PingMessage, nil, time.Now().Add(socketWriteWait)); err != nil {
+				fmt.Printf("websocket ping failed: %v\n", err)
+				return
+			}
+		}
+	}
+}
+
+func handleChatError(err error, socket componentWriter, threadId string, app *pocketbase.PocketBase) {
 	if err != nil {
 		errorMessage := fmt.Sprintf("Encountered an error: %v", err)
 
+		// errors continue the active path so they show up where they happened
+		parentId := ""
+		if tree, treeErr := loadMessageTree(threadId, app); treeErr == nil {
+			parentId = tree.activeLeafId()
+		}
+
 		chatCollection, _ := app.Dao().FindCollectionByNameOrId("chat")
 		chatErrorRecord := models.NewRecord(chatCollection)
 		form := forms.NewRecordUpsert(app, chatErrorRecord)
 		form.LoadData(map[string]any{
 			"thread_id": threadId,
-			"message": errorMessage,
-			"sender": "system",
-			"model": "error",
+			"parent_id": parentId,
+			"message":   errorMessage,
+			"sender":    "system",
+			"model":     "error",
 		})
 		if submitErr := form.Submit(); submitErr != nil {
 			fmt.Printf("failed to submit chat error message: %v\n", submitErr)
 		}
 
 		errorResponse := templates.ErrorChatResponse(errorMessage)
-		var htmlBuf bytes.Buffer
-		renderErr := errorResponse.Render(context.Background(), &htmlBuf)
-		if renderErr != nil {
-			fmt.Printf("failed to render chat error message: %v\n", renderErr)
+		if writeErr := socket.writeComponent(errorResponse); writeErr != nil {
+			fmt.Printf("failed to write chat error response to websocket: %v\n", writeErr)
 		}
-		htmlStr := htmlBuf.String()
-		err = ws.WriteMessage(websocket.TextMessage, []byte(htmlStr))
-		if err != nil {
-			fmt.Printf("failed to write chat error response to websocket: %v\n", renderErr)
+	}
+}
+
+// get chats leading up to lastMessageId, create ChatCompletionMessage so model has context
+func buildChatHistory(threadId string, lastMessageId string, app *pocketbase.PocketBase) (*chatContext, error) {
+	threadRecord, err := app.Dao().FindRecordById("chat_meta", threadId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch thread record: %w", err)
+	}
+
+	tree, err := loadMessageTree(threadId, app)
+	if err != nil {
+		return nil, err
+	}
+
+	chatHistory := &chatContext{ThreadRecord: threadRecord}
+
+	// thread system prompt takes priority over the default from settings
+	systemPrompt := threadRecord.GetString("system_prompt")
+	if systemPrompt == "" {
+		systemPrompt = loadDefaultSystemPrompt(app)
+	}
+	if systemPrompt != "" {
+		chatHistory.System = append(chatHistory.System, newContextMessage("", openai.ChatMessageRoleSystem, systemPrompt))
+	}
+
+	// without tools the calls and their results are sent as plain text, APIs reject calls to tools they weren't given
+	toolsEnabled := len(threadTools(threadRecord)) > 0
+	path := tree.pathTo(lastMessageId)
+	answered := make(map[string]bool)
+	for _, message := range path {
+		if message.Sender == "tool" {
+			answered[message.ToolCall.Id] = true
 		}
 	}
+
+	// error messages are stored with the system sender, skip them
+	for _, message := range path {
+		if message.Sender == "human" {
+			humanMessage, err := humanContextMessage(message, app)
+			if err != nil {
+				return nil, err
+			}
+			chatHistory.Messages = append(chatHistory.Messages, humanMessage)
+		} else if message.Sender == "model" && tree.messages[message.ParentId].Compare == "all" {
+			chatHistory.Messages = append(chatHistory.Messages, newContextMessage(message.Id, openai.ChatMessageRoleAssistant, tree.combinedAnswers(message.ParentId)))
+		} else if message.Sender == "model" && toolsEnabled && len(message.ToolCalls) > 0 {
+			modelMessage := modelContextMessage(message, answered)
+			// a call stopped before it ran leaves nothing to send
+			if modelMessage.Message.Content != "" || len(modelMessage.Message.ToolCalls) > 0 {
+				chatHistory.Messages = append(chatHistory.Messages, modelMessage)
+			}
+		} else if message.Sender == "model" && len(message.ToolCalls) > 0 {
+			if message.Message != "" {
+				chatHistory.Messages = append(chatHistory.Messages, newContextMessage(message.Id, openai.ChatMessageRoleAssistant, message.Message))
+			}
+		} else if message.Sender == "model" {
+			chatHistory.Messages = append(chatHistory.Messages, newContextMessage(message.Id, openai.ChatMessageRoleAssistant, message.Message))
+		} else if message.Sender == "tool" && toolsEnabled {
+			chatHistory.Messages = append(chatHistory.Messages, toolContextMessage(message))
+		} else if message.Sender == "tool" {
+			toolText := "Result of " + message.ToolCall.Name + " " + message.ToolCall.Arguments + ":\n" + message.Message
+			chatHistory.Messages = append(chatHistory.Messages, newContextMessage(message.Id, openai.ChatMessageRoleAssistant, toolText))
+		}
+	}
+
+	return chatHistory, nil
 }
 
 func OpenChatSocket(selectedModel *string, c echo.Context, app *pocketbase.PocketBase) error {
 	fmt.Println("websocket triggered")
-	
+
 	var upgrader = websocket.Upgrader{
-       CheckOrigin: func(r *http.Request) bool {
-           // Allow all connections by returning true.
-           // For better security, you can specify conditions here.
-           return true
-
-           // Example for specific origin:
-           // return r.Header.Get("Origin") == "tauri://localhost"
-       },
-   }
-	
+		CheckOrigin: func(r *http.Request) bool {
+			// Allow all connections by returning true.
+			// For better security, you can specify conditions here.
+			return true
+
+			// Example for specific origin:
+			// return r.Header.Get("Origin") == "tauri://localhost"
+		},
+	}
+
 	ws, err := upgrader.Upgrade(c.Response(), c.Request(), nil)
 	if err != nil {
 		fmt.Println("websocket upgrade failed")
@@ -81,11 +211,19 @@ func OpenChatSocket(selectedModel *string, c echo.Context, app *pocketbase.Pocke
 	}
 	defer ws.Close()
 
-	chatCollection, err := app.Dao().FindCollectionByNameOrId("chat")
-	if err != nil {
-		return err
-	}
-	
+	socket := &chatSocket{ws: ws}
+	// generations outlive the socket, they just stop writing to it
+	defer generationJobs.unsubscribe(socket)
+
+	ws.SetReadDeadline(time.Now().Add(socketPongWait))
+	ws.SetPongHandler(func(string) error {
+		return ws.SetReadDeadline(time.Now().Add(socketPongWait))
+	})
+	done := make(chan struct{})
+	defer close(done)
+	go socket.keepAlive(done)
+	generationJobs.connect(socket)
+
 	for {
 		// read
 		_, msg, err := ws.ReadMessage()
@@ -94,226 +232,688 @@ func OpenChatSocket(selectedModel *string, c echo.Context, app *pocketbase.Pocke
 			fmt.Println(err)
 			return err
 		}
-		fmt.Printf("%s\n", msg)
 
 		var htmxMsg HTMXSocketMsg
 		err = json.Unmarshal(msg, &htmxMsg)
 		if err != nil {
 			fmt.Printf("error parsing message: %v\n", err)
-			handleChatError(err, ws, htmxMsg.ThreadId, app)
+			handleChatError(err, socket, htmxMsg.ThreadId, app)
 			continue
 		}
+		htmxMsg.PromptVariables = promptVariables(msg)
 		fmt.Println(htmxMsg)
 
-		if htmxMsg.Msg != "" {
-
-			// fetch selected model config
-			userRecord, err := app.Dao().FindFirstRecordByData("users", "username", "default")
-			if err != nil {
-				fmt.Printf("failed to fetch user config data: %v\n", err)
-				handleChatError(err, ws, htmxMsg.ThreadId, app)
-				continue
+		var generate func(ctx context.Context)
+		switch {
+		case htmxMsg.Action == "stop":
+			generationJobs.cancelThread(htmxMsg.ThreadId)
+			continue
+		case htmxMsg.Action == "resume":
+			after, _ := strconv.Atoi(htmxMsg.After)
+			resumeGeneration(htmxMsg.MessageId, after, socket, app)
+			continue
+		case htmxMsg.Action == "tool-approve":
+			toolApprovals.answer(htmxMsg.MessageId, true)
+			continue
+		case htmxMsg.Action == "tool-deny":
+			toolApprovals.answer(htmxMsg.MessageId, false)
+			continue
+		case htmxMsg.Action == "regenerate":
+			generate = func(ctx context.Context) {
+				regenerateChatResponse(ctx, htmxMsg, socket, app)
 			}
-
-			selectedApiRecord, err := app.Dao().FindRecordById("apis", userRecord.GetString("selected_api"))
-			if err != nil {
-				fmt.Printf("failed to fetch selected api record %v\n", err)
-				handleChatError(err, ws, htmxMsg.ThreadId, app)
-				continue
+		case htmxMsg.Action == "respond":
+			generate = func(ctx context.Context) {
+				respondToMessage(ctx, htmxMsg.ThreadId, htmxMsg.MessageId, false, socket, app)
 			}
-
-			chatModelName := selectedApiRecord.GetString("name")
-			if userRecord.GetString("selected_model_name") != "" {
-				chatModelName = chatModelName + "-" + userRecord.GetString("selected_model_name")
+		case (htmxMsg.Msg != "" || htmxMsg.AttachmentDraft != "") && htmxMsg.Compare != "":
+			generate = func(ctx context.Context) {
+				compareChatResponse(ctx, htmxMsg, socket, app)
 			}
-
-			// create message and response upserts
-			requestRecord := models.NewRecord(chatCollection)
-			form := forms.NewRecordUpsert(app, requestRecord)
-
-			// store message from human
-			form.LoadData(map[string]any{
-				"thread_id": htmxMsg.ThreadId,
-				"message":   htmxMsg.Msg,
-				"sender":    "human",
-				"model":     chatModelName,
-			})
-			if err := form.Submit(); err != nil {
-				fmt.Printf("Failed to submit user message to chat DB: %v\n", err)
-				handleChatError(err, ws, htmxMsg.ThreadId, app)
-				continue
+		case htmxMsg.Msg != "" || htmxMsg.AttachmentDraft != "":
+			generate = func(ctx context.Context) {
+				generateChatResponse(ctx, htmxMsg, socket, app)
 			}
+		default:
+			continue
+		}
 
-			// initialize new record for model, load data and submit at end
-			modelMessageRecord := models.NewRecord(chatCollection)
-			modelForm := forms.NewRecordUpsert(app, modelMessageRecord)
-			modelForm.LoadData(map[string]any{
-				"thread_id": htmxMsg.ThreadId,
-				"message":   "",
-				"sender":    "model",
-			})
-			if err := modelForm.Submit(); err != nil {
-				fmt.Printf("Failed to initialize model message in chat DB: %v\n", err)
-				handleChatError(err, ws, htmxMsg.ThreadId, app)
-				continue
-			}
+		// not tied to the socket, a dropped connection still lets the response finish and save
+		ctx, cancel := context.WithCancel(context.Background())
+		if !generationJobs.reserveThread(htmxMsg.ThreadId, cancel) {
+			cancel()
+			err := errors.New("a response is still being generated, stop it before sending another message")
+			handleChatError(err, socket, htmxMsg.ThreadId, app)
+			continue
+		}
 
-			// send the initial response skeleton
-			chatParams := templates.LoadedMessageParams{
-				Id:          modelMessageRecord.Id,
-				Message:     htmxMsg.Msg,
-				Model:       chatModelName,
-				Useful:		 false,
-			}
-			chatComponent := templates.InitChatMessage(chatParams)
+		go func() {
+			defer func() {
+				generationJobs.releaseThread(htmxMsg.ThreadId)
+				cancel()
+			}()
+			generate(ctx)
+		}()
+	}
+}
 
-			// create ws write function to handle these
-			var htmlBuf bytes.Buffer
-			err = chatComponent.Render(context.Background(), &htmlBuf)
-			if err != nil {
-				handleChatError(err, ws, htmxMsg.ThreadId, app)
-				continue
-			}
-			htmlStr := htmlBuf.String()
-			err = ws.WriteMessage(websocket.TextMessage, []byte(htmlStr))
-			if err != nil {
-				handleChatError(err, ws, htmxMsg.ThreadId, app)
-				continue
-			}
+// fetch the API and model currently selected in the chat window
+// returns the API record, the model name requested from it, and the name shown in the thread
+func loadSelectedModel(app *pocketbase.PocketBase) (*models.Record, string, string, error) {
+	userRecord, err := app.Dao().FindFirstRecordByData("users", "username", "default")
+	if err != nil {
+		return nil, "", "", fmt.Errorf("failed to fetch user config data: %w", err)
+	}
 
-			// get all chats from thread, create ChatCompletionMessage so model has context
-			var messages []templates.LoadedMessageParams
-			app.Dao().DB().
-				Select("*").
-				From("chat").
-				Where(dbx.NewExp("thread_id = {:id}", dbx.Params{"id": htmxMsg.ThreadId})).
-				All(&messages)
-
-			// TODO: utilize ChatMessageRoleSystem for system prompt (ex: "You are a helpful assistant")
-			var chatHistory []openai.ChatCompletionMessage
-			for _, message := range messages {
-				if message.Sender == "human" {
-					chatHistory = append(chatHistory, openai.ChatCompletionMessage{
-						Role:    openai.ChatMessageRoleUser,
-						Content: message.Message,
-					})
-				} else {
-					chatHistory = append(chatHistory, openai.ChatCompletionMessage{
-						Role:    openai.ChatMessageRoleAssistant,
-						Content: message.Message,
-					})
-				}
-			}
+	selectedApiRecord, err := app.Dao().FindRecordById("apis", userRecord.GetString("selected_api"))
+	if err != nil {
+		return nil, "", "", fmt.Errorf("failed to fetch selected api record: %w", err)
+	}
+
+	modelName := userRecord.GetString("selected_model_name")
+
+	return selectedApiRecord, modelName, displayModelName(selectedApiRecord, modelName), nil
+}
+
+// name a model is shown and counted under, the API name joined with the model
+func displayModelName(apiRecord *models.Record, modelName string) string {
+	if modelName == "" {
+		return apiRecord.GetString("name")
+	}
+	return apiRecord.GetString("name") + "-" + modelName
+}
+
+// a model message record waiting to be filled in by a streamed response
+type chatGeneration struct {
+	ThreadId      string
+	Record        *models.Record
+	ApiRecord     *models.Record
+	ModelName     string
+	ChatModelName string
+	History       []openai.ChatCompletionMessage
+	Params        templates.GenerationParams
+	// local estimate of the history size, used when the API doesn't report usage
+	PromptTokens int
+	// compare answers stay with the model of their column
+	NoFallbacks bool
+	// tools the model may call, none for compare answers
+	Tools []chatTool
+}
+
+// save a new message at the end of the active path and stream a response to it
+func generateChatResponse(ctx context.Context, htmxMsg HTMXSocketMsg, socket *chatSocket, app *pocketbase.PocketBase) {
+	tree, err := loadMessageTree(htmxMsg.ThreadId, app)
+	if err != nil {
+		handleChatError(err, socket, htmxMsg.ThreadId, app)
+		return
+	}
+
+	_, _, chatModelName, err := loadSelectedModel(app)
+	if err != nil {
+		fmt.Println(err)
+		handleChatError(err, socket, htmxMsg.ThreadId, app)
+		return
+	}
+
+	message, promptFields, err := expandPromptCommand(htmxMsg.Msg, htmxMsg.PromptVariables, app)
+	if err != nil {
+		handleChatError(err, socket, htmxMsg.ThreadId, app)
+		return
+	}
+
+	// store message from human
+	humanData := map[string]any{
+		"thread_id": htmxMsg.ThreadId,
+		"parent_id": tree.activeLeafId(),
+		"message":   message,
+		"sender":    "human",
+		"model":     chatModelName,
+	}
+	maps.Copy(humanData, promptFields)
+	requestRecord, err := saveHumanMessage(htmxMsg.AttachmentDraft, humanData, app)
+	if err != nil {
+		fmt.Printf("Failed to submit user message to chat DB: %v\n", err)
+		handleChatError(err, socket, htmxMsg.ThreadId, app)
+		return
+	}
+
+	respondToMessage(ctx, htmxMsg.ThreadId, requestRecord.Id, true, socket, app)
+}
+
+// stream a model response to a stored human message
+// showHuman renders the human message too, it is already displayed after an edit
+func respondToMessage(ctx context.Context, threadId string, humanMessageId string, showHuman bool, socket *chatSocket, app *pocketbase.PocketBase) {
+	chatCollection, err := app.Dao().FindCollectionByNameOrId("chat")
+	if err != nil {
+		handleChatError(err, socket, threadId, app)
+		return
+	}
+
+	humanRecord, err := app.Dao().FindRecordById("chat", humanMessageId)
+	if err != nil {
+		handleChatError(err, socket, threadId, app)
+		return
+	}
+	threadId = humanRecord.GetString("thread_id")
+
+	// fetch selected model config
+	selectedApiRecord, modelName, chatModelName, err := loadSelectedModel(app)
+	if err != nil {
+		fmt.Println(err)
+		handleChatError(err, socket, threadId, app)
+		return
+	}
+
+	params := threadGenerationParams(threadId, selectedApiRecord, app)
+
+	// initialize new record for model, load data and submit at end
+	modelMessageRecord := models.NewRecord(chatCollection)
+	modelForm := forms.NewRecordUpsert(app, modelMessageRecord)
+	modelForm.LoadData(map[string]any{
+		"thread_id": threadId,
+		"parent_id": humanRecord.Id,
+		"message":   "",
+		"sender":    "model",
+	})
+	if err := modelForm.Submit(); err != nil {
+		fmt.Printf("Failed to initialize model message in chat DB: %v\n", err)
+		handleChatError(err, socket, threadId, app)
+		return
+	}
+
+	// send the initial response skeleton
+	humanParams := templates.LoadedMessageParams{
+		Id:          humanRecord.Id,
+		Message:     humanRecord.GetString("message"),
+		ThreadId:    threadId,
+		Attachments: loadAttachments(humanRecord),
+		PromptName:  humanRecord.GetString("prompt_name"),
+	}
+	chatParams := templates.LoadedMessageParams{
+		Id:               modelMessageRecord.Id,
+		Model:            chatModelName,
+		Useful:           false,
+		GenerationParams: params,
+	}
+	skeleton := templates.InitModelMessage(chatParams)
+	if showHuman {
+		skeleton = templates.InitChatMessage(humanParams, chatParams)
+	}
+	if err := socket.writeComponent(skeleton); err != nil {
+		handleChatError(err, socket, threadId, app)
+		return
+	}
+
+	chatHistory, err := buildChatHistory(threadId, humanRecord.Id, app)
+	if err != nil {
+		handleChatError(err, socket, threadId, app)
+		return
+	}
+	chatHistory.fit(ctx, selectedApiRecord, modelName, false, app)
+
+	runChatGeneration(ctx, chatGeneration{
+		ThreadId:      threadId,
+		Record:        modelMessageRecord,
+		ApiRecord:     selectedApiRecord,
+		ModelName:     modelName,
+		ChatModelName: chatModelName,
+		History:       chatHistory.history(),
+		Params:        params,
+		PromptTokens:  chatHistory.tokens(),
+		Tools:         threadTools(chatHistory.ThreadRecord),
+	}, socket, app)
+}
+
+// stream a sibling branch for an existing model message using the same history
+// the currently selected API and model are used so responses can be compared
+func regenerateChatResponse(ctx context.Context, htmxMsg HTMXSocketMsg, socket *chatSocket, app *pocketbase.PocketBase) {
+	replacedRecord, err := app.Dao().FindRecordById("chat", htmxMsg.MessageId)
+	if err != nil {
+		handleChatError(err, socket, htmxMsg.ThreadId, app)
+		return
+	}
+	threadId := replacedRecord.GetString("thread_id")
+	parentId := replacedRecord.GetString("parent_id")
+
+	selectedApiRecord, modelName, chatModelName, err := loadSelectedModel(app)
+	if err != nil {
+		fmt.Println(err)
+		handleChatError(err, socket, threadId, app)
+		return
+	}
 
-			config := openai.DefaultConfig(selectedApiRecord.GetString("api_key"))
-			config.BaseURL = selectedApiRecord.GetString("url")
-			chatgptClient := openai.NewClientWithConfig(config)
+	params := threadGenerationParams(threadId, selectedApiRecord, app)
 
-			req := openai.ChatCompletionRequest{
-				Model: userRecord.GetString("selected_model_name"),
-				// MaxTokens: 20,
-				Messages: chatHistory,
-				Stream:   true,
+	chatHistory, err := buildChatHistory(threadId, parentId, app)
+	if err != nil {
+		handleChatError(err, socket, threadId, app)
+		return
+	}
+	chatHistory.fit(ctx, selectedApiRecord, modelName, false, app)
+
+	chatCollection, err := app.Dao().FindCollectionByNameOrId("chat")
+	if err != nil {
+		handleChatError(err, socket, threadId, app)
+		return
+	}
+
+	modelMessageRecord := models.NewRecord(chatCollection)
+	modelForm := forms.NewRecordUpsert(app, modelMessageRecord)
+	modelForm.LoadData(map[string]any{
+		"thread_id": threadId,
+		"parent_id": parentId,
+		"message":   "",
+		"sender":    "model",
+		"model":     chatModelName,
+	})
+	if err := modelForm.Submit(); err != nil {
+		fmt.Printf("Failed to initialize regenerated message in chat DB: %v\n", err)
+		handleChatError(err, socket, threadId, app)
+		return
+	}
+
+	siblingNav, err := selectSibling(threadId, parentId, modelMessageRecord.Id, app)
+	if err != nil {
+		handleChatError(err, socket, threadId, app)
+		return
+	}
+
+	// swap the displayed branch for the new response skeleton
+	chatParams := templates.LoadedMessageParams{
+		Id:               modelMessageRecord.Id,
+		Model:            chatModelName,
+		ParentId:         parentId,
+		Siblings:         siblingNav,
+		GenerationParams: params,
+	}
+	if err := socket.writeComponent(templates.ModelMessageSwap(replacedRecord.Id, chatParams)); err != nil {
+		handleChatError(err, socket, threadId, app)
+		return
+	}
+
+	runChatGeneration(ctx, chatGeneration{
+		ThreadId:      threadId,
+		Record:        modelMessageRecord,
+		ApiRecord:     selectedApiRecord,
+		ModelName:     modelName,
+		ChatModelName: chatModelName,
+		History:       chatHistory.history(),
+		Params:        params,
+		PromptTokens:  chatHistory.tokens(),
+		Tools:         threadTools(chatHistory.ThreadRecord),
+	}, socket, app)
+}
+
+// what one request streamed before it ended
+type streamResult struct {
+	Text string
+	// thinking of a reasoning model, kept out of Text
+	Reasoning string
+	ToolCalls []openai.ToolCall
+	Stopped   bool
+	// reported by the API, nil when it has to be estimated
+	Usage *openai.Usage
+	// when the request was sent, its first token arrived and the stream ended
+	Started      time.Time
+	FirstToken   time.Time
+	Ended        time.Time
+	FinishReason string
+}
+
+// stream one request to one API into the job
+// returns the error that ended it, nil once the stream finished or was stopped
+func streamAttempt(ctx context.Context, job *generationJob, generation chatGeneration, target chatTarget, result *streamResult, app *pocketbase.PocketBase) error {
+	provider, err := newProvider(target.ApiRecord)
+	if err != nil {
+		return err
+	}
+
+	waited := false
+	release, err := apiLimits.acquire(ctx, target.ApiRecord, func() {
+		waited = true
+		if err := job.writeComponent(templates.QueuedStatus(job.MessageId, target.ApiRecord.GetString("name"))); err != nil {
+			fmt.Println("socket write failure")
+			fmt.Println(err)
+		}
+	})
+	if err != nil {
+		// stopped while waiting for the API
+		result.Stopped = true
+		return nil
+	}
+	defer release()
+	if waited {
+		if err := job.writeComponent(templates.ClearResponseStatus(job.MessageId)); err != nil {
+			fmt.Println("socket write failure")
+			fmt.Println(err)
+		}
+	}
+
+	req := openai.ChatCompletionRequest{
+		Model:    target.ModelName,
+		Messages: generation.History,
+		Stream:   true,
+	}
+	applyGenerationParams(&req, generation.Params)
+	if len(generation.Tools) > 0 {
+		req.Tools = requestTools(generation.Tools)
+	}
+
+	// timed from after the wait for a free slot, so a busy API doesn't count against its model
+	result.Started = time.Now()
+	defer func() {
+		result.Ended = time.Now()
+	}()
+	stream, err := provider.StreamChat(ctx, req)
+	if err != nil {
+		fmt.Printf("ChatCompletionStream error: %v\n", err)
+		return err
+	}
+	defer func() {
+		result.Usage = stream.Usage()
+		stream.Close()
+	}()
+
+	fmt.Printf("Stream response: ")
+
+	// thinking comes in a field of its own or in <think> tags at the start of the content
+	var parser thinkParser
+	writeStream := func(text string, reasoning string) {
+		if result.Reasoning == "" {
+			reasoning = strings.TrimLeft(reasoning, thinkSpace)
+		}
+		result.Text += text
+		result.Reasoning += reasoning
+		if reasoning != "" {
+			if err := job.writeReasoning(reasoning); err != nil {
+				fmt.Println("socket write failure")
+				fmt.Println(err)
 			}
-			stream, err := chatgptClient.CreateChatCompletionStream(context.Background(), req)
-			if err != nil {
-				fmt.Printf("ChatCompletionStream error: %v\n", err)
-				handleChatError(err, ws, htmxMsg.ThreadId, app)
-				continue
+		}
+		if text != "" {
+			if err := job.writeChunk(text); err != nil {
+				fmt.Println("socket write failure")
+				fmt.Println(err)
 			}
-			defer stream.Close()
+		}
+	}
+	defer func() {
+		writeStream(parser.flush())
+	}()
 
-			fmt.Printf("Stream response: ")
+	lastSave := time.Now()
+	for {
+		response, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			fmt.Println("\nStream finished")
+			return nil
+		}
 
-			fullResponse := ""
-			for {
-				response, err := stream.Recv()
-				if errors.Is(err, io.EOF) {
-					fmt.Println("\nStream finished")
-					break
-				}
+		// stop requested by the user, keep whatever was generated so far
+		if ctx.Err() != nil {
+			fmt.Println("\nStream stopped")
+			result.Stopped = true
+			return nil
+		}
 
-				if err != nil {
-					fmt.Printf("\nStream error: %v\n", err)
-					handleChatError(err, ws, htmxMsg.ThreadId, app)
-					continue
-				}
+		if err != nil {
+			fmt.Printf("\nStream error: %v\n", err)
+			return err
+		}
 
-				fullResponse += response.Choices[0].Delta.Content
+		if len(response.Choices) == 0 {
+			continue
+		}
+
+		choice := response.Choices[0]
+		reasoning := stream.Reasoning()
+		if result.FirstToken.IsZero() && (choice.Delta.Content != "" || reasoning != "" || len(choice.Delta.ToolCalls) > 0) {
+			result.FirstToken = time.Now()
+		}
+		if choice.FinishReason != "" {
+			result.FinishReason = string(choice.FinishReason)
+		}
 
-				responseChunkComponent := templates.ChatStreamChunk(modelMessageRecord.Id, response.Choices[0].Delta.Content)
+		text, thinking := parser.feed(choice.Delta.Content)
+		writeStream(text, reasoning+thinking)
+		result.ToolCalls = mergeToolCallDeltas(result.ToolCalls, choice.Delta.ToolCalls)
 
-				var htmlBuf bytes.Buffer
+		// keep the partial response in case the server goes down mid-stream
+		if time.Since(lastSave) > partialSaveInterval {
+			generation.Record.Set("message", result.Text)
+			generation.Record.Set("reasoning", result.Reasoning)
+			if err := app.Dao().SaveRecord(generation.Record); err != nil {
+				fmt.Printf("Failed to save partial model message: %v\n", err)
+			}
+			lastSave = time.Now()
+		}
+	}
+}
 
-				err = responseChunkComponent.Render(context.Background(), &htmlBuf)
-				if err != nil {
-					fmt.Println("templ stream chunk render error")
-					fmt.Println(err)
-					handleChatError(err, ws, htmxMsg.ThreadId, app)
-					continue
-				}
-				htmlStr := htmlBuf.String()
-				fmt.Println(htmlStr)
-				err = ws.WriteMessage(websocket.TextMessage, []byte(htmlStr))
-				if err != nil {
+// stream the completion into the chat window and save the result to the model message record
+// updates go through a job so a client that reconnects can pick the stream back up
+// retryable failures are retried with backoff, then the fallbacks of the API are tried in order
+// returns the tools the model called, none if the response was stopped or failed
+// the job is left open, the caller finishes it once nothing else is sent for the message
+func streamChatGeneration(ctx context.Context, generation chatGeneration, job *generationJob, app *pocketbase.PocketBase) templates.ToolCallList {
+	messageId := generation.Record.Id
+	var result streamResult
+	var route templates.RouteList
+	var err error
+	for _, target := range generationTargets(generation, app) {
+		attempt := templates.RouteAttempt{Model: target.Name}
+		for {
+			// an attempt that failed partway is started over
+			if result.Text != "" || result.Reasoning != "" || len(result.ToolCalls) > 0 {
+				if err := job.restart(); err != nil {
 					fmt.Println("socket write failure")
 					fmt.Println(err)
-					handleChatError(err, ws, htmxMsg.ThreadId, app)
-					continue
 				}
 			}
+			result = streamResult{}
 
-			// record model message in DB
-			modelForm.LoadData(map[string]any{
-				"thread_id": htmxMsg.ThreadId,
-				"message":   fullResponse,
-				"sender":    "model",
-				"model":     chatModelName,
-			})
-
-			if err := modelForm.Submit(); err != nil {
-				fmt.Printf("Failed to submit model message to chat DB: %v\n", err)
-				handleChatError(err, ws, htmxMsg.ThreadId, app)
-				continue
+			attemptCtx, hint := withRetryHint(ctx)
+			err = streamAttempt(attemptCtx, job, generation, target, &result, app)
+			if err == nil || !retryableError(err) || attempt.Retries >= maxRetries {
+				break
+			}
+			delay, worthWaiting := retryDelay(attempt.Retries, hint.get())
+			if !worthWaiting {
+				break
 			}
 
-			threadRecord, err := app.Dao().FindRecordById("chat_meta", htmxMsg.ThreadId)
-			if err != nil {
-				fmt.Printf("Error reading thread metadata: %v\n", err)
-				handleChatError(err, ws, htmxMsg.ThreadId, app)
-				continue
+			attempt.Retries++
+			fmt.Printf("Retrying %s in %s: %v\n", target.Name, delay, err)
+			if err := job.writeComponent(templates.RetryStatus(messageId, retryStatusMessage(err, delay, attempt.Retries))); err != nil {
+				fmt.Println("socket write failure")
+				fmt.Println(err)
 			}
+			if !sleepContext(ctx, delay) {
+				break
+			}
+		}
 
-			lastMessageTime := types.NowDateTime()
-			responseChunkComponent := templates.LastMessageTimestamp(htmxMsg.ThreadId, modelMessageRecord.Id, lastMessageTime)
+		// stopped while waiting to retry
+		if err != nil && ctx.Err() != nil {
+			result.Stopped = true
+			err = nil
+		}
+		if err != nil {
+			attempt.Error = err.Error()
+		}
+		route = append(route, attempt)
 
-			// sending HTML along websocket needs to be pulled to it's own function
-			var lastMessageTimeBuf bytes.Buffer
+		if err == nil {
+			generation.ApiRecord = target.ApiRecord
+			generation.ModelName = target.ModelName
+			generation.ChatModelName = target.Name
+			break
+		}
+		fmt.Printf("Giving up on %s: %v\n", target.Name, err)
+	}
 
-			err = responseChunkComponent.Render(context.Background(), &lastMessageTimeBuf)
-			if err != nil {
-				fmt.Println("templ stream chunk render error")
+	if err != nil {
+		handleChatError(err, job, generation.ThreadId, app)
+	}
+	if route.Notable() {
+		if err := job.writeComponent(templates.RouteStatus(messageId, route)); err != nil {
+			fmt.Println("socket write failure")
+			fmt.Println(err)
+		}
+	}
+
+	// chat templates that open the <think> block themselves leave only the closing tag in the response
+	if result.Reasoning == "" && strings.Contains(result.Text, thinkCloseTag) {
+		if text, reasoning := splitReasoning(result.Text, opensThinkBlock(generation.ApiRecord)); text != result.Text {
+			result.Text, result.Reasoning = text, reasoning
+			if err := job.restart(); err != nil {
+				fmt.Println("socket write failure")
 				fmt.Println(err)
-				handleChatError(err, ws, htmxMsg.ThreadId, app)
-				continue
 			}
-			lastMessageTimeStr := lastMessageTimeBuf.String()
-			fmt.Println(lastMessageTimeStr)
-			err = ws.WriteMessage(websocket.TextMessage, []byte(lastMessageTimeStr))
-			if err != nil {
+			if result.Reasoning != "" {
+				if err := job.writeReasoning(result.Reasoning); err != nil {
+					fmt.Println("socket write failure")
+					fmt.Println(err)
+				}
+			}
+			if err := job.writeChunk(result.Text); err != nil {
 				fmt.Println("socket write failure")
 				fmt.Println(err)
-				handleChatError(err, ws, htmxMsg.ThreadId, app)
-				continue
 			}
+		}
+	}
 
-			threadRecord.Set("last_message_timestamp", types.NowDateTime())
-			threadRecord.Set("last_message", fullResponse[0:10])
-			if err := app.Dao().SaveRecord(threadRecord); err != nil {
-				fmt.Printf("Error updating thread metadata: %v\n", err)
-				handleChatError(err, ws, htmxMsg.ThreadId, app)
-				continue
-			}
+	fullResponse := result.Text
+	reasoning := strings.TrimSpace(result.Reasoning)
+	stopped := result.Stopped
+	failed := err != nil
+	toolCalls := result.ToolCalls
+
+	// calls count toward the estimate like the text does
+	calls := storedToolCalls(toolCalls)
+	estimatedText := reasoning + " " + fullResponse
+	for _, call := range calls {
+		estimatedText += " " + call.Name + " " + call.Arguments
+	}
+	tokenUsage, estimated := responseUsage(result.Usage, generation.PromptTokens, estimatedText)
+	var metrics templates.LoadedMessageParams
+	if !failed {
+		metrics = responseMetrics(result, tokenUsage.CompletionTokens)
+	}
+
+	// record model message in DB
+	modelForm := forms.NewRecordUpsert(app, generation.Record)
+	modelForm.LoadData(map[string]any{
+		"message":           fullResponse,
+		"reasoning":         reasoning,
+		"model":             generation.ChatModelName,
+		"stopped":           stopped,
+		"generation_params": generation.Params,
+		"prompt_tokens":     tokenUsage.PromptTokens,
+		"completion_tokens": tokenUsage.CompletionTokens,
+		"total_tokens":      tokenUsage.TotalTokens,
+		"usage_estimated":   estimated,
+		"tool_calls":        calls,
+		"route":             route,
+		"timestamp":         metrics.Timestamp,
+		"ttft_ms":           metrics.TTFTMs,
+		"duration_ms":       metrics.DurationMs,
+		"tokens_per_second": metrics.TokensPerSecond,
+		"finish_reason":     metrics.FinishReason,
+	})
+
+	if err := modelForm.Submit(); err != nil {
+		fmt.Printf("Failed to submit model message to chat DB: %v\n", err)
+		handleChatError(err, job, generation.ThreadId, app)
+		return nil
+	}
+	if stopped || failed {
+		calls = nil
+	}
+
+	if stopped {
+		if err := job.writeComponent(templates.StoppedMarker(messageId)); err != nil {
+			fmt.Println("socket write failure")
+			fmt.Println(err)
 		}
 	}
+
+	// blocks rendered one at a time while streaming can belong together, like the items of a list
+	if err := job.writeComponent(templates.MessageContentSwap(messageId, fullResponse)); err != nil {
+		fmt.Println("socket write failure")
+		fmt.Println(err)
+	}
+
+	// collapse the thinking now that the answer is there
+	if reasoning != "" {
+		if err := job.writeComponent(templates.MessageReasoningSwap(messageId, reasoning)); err != nil {
+			fmt.Println("socket write failure")
+			fmt.Println(err)
+		}
+	}
+
+	metrics.Id = messageId
+	metrics.CompletionTokens = tokenUsage.CompletionTokens
+	if err := job.writeComponent(templates.MessageMetricsSwap(metrics)); err != nil {
+		fmt.Println("socket write failure")
+		fmt.Println(err)
+	}
+
+	threadRecord, err := app.Dao().FindRecordById("chat_meta", generation.ThreadId)
+	if err != nil {
+		fmt.Printf("Error reading thread metadata: %v\n", err)
+		handleChatError(err, job, generation.ThreadId, app)
+		return nil
+	}
+
+	lastMessageTime := types.NowDateTime()
+	lastMessageTimeComponent := templates.LastMessageTimestamp(generation.ThreadId, messageId, lastMessageTime)
+	if err := job.writeComponent(lastMessageTimeComponent); err != nil {
+		fmt.Println("socket write failure")
+		fmt.Println(err)
+	}
+
+	threadRecord.Set("last_message_timestamp", types.NowDateTime())
+	threadRecord.Set("last_message", truncateMessage(fullResponse, 10))
+	if err := app.Dao().SaveRecord(threadRecord); err != nil {
+		fmt.Printf("Error updating thread metadata: %v\n", err)
+		handleChatError(err, job, generation.ThreadId, app)
+		return nil
+	}
+
+	// name the thread once it has its first answer, it outlives the job so it goes to every open socket
+	if !stopped && fullResponse != "" && threadNeedsTitle(threadRecord) {
+		go generateThreadTitle(generation, fullResponse, generationJobs, app)
+	}
+
+	if totals, err := threadUsage(generation.ThreadId, app); err == nil {
+		if err := job.writeComponent(templates.ThreadUsageSwap(generation.ThreadId, totals)); err != nil {
+			fmt.Println("socket write failure")
+			fmt.Println(err)
+		}
+	} else {
+		fmt.Printf("Error totaling thread usage: %v\n", err)
+	}
+
+	// show how much of the context window the next message will use
+	contextUsage, messages, err := previewThreadContext(generation.ThreadId, app)
+	if err != nil {
+		fmt.Printf("Error estimating thread context: %v\n", err)
+		return calls
+	}
+	if err := job.writeComponent(templates.ContextUpdate(contextUsage, messages)); err != nil {
+		fmt.Println("socket write failure")
+		fmt.Println(err)
+	}
+
+	return calls
+}
+
+// shorten a message for previews without splitting multi-byte characters
+func truncateMessage(message string, length int) string {
+	runes := []rune(message)
+	if len(runes) <= length {
+		return message
+	}
+	return string(runes[:length])
 }
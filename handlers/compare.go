@@ -0,0 +1,313 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/erikmillergalow/htmx-llmchat/templates"
+
+	"github.com/labstack/echo/v5"
+
+	"github.com/pocketbase/pocketbase"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// an API and model a compare prompt is sent to, stored in the user's compare_models
+type compareModel struct {
+	ApiId string `json:"api"`
+	Model string `json:"model"`
+}
+
+func loadCompareModels(userRecord *models.Record) []compareModel {
+	var compareModels []compareModel
+	raw := userRecord.GetString("compare_models")
+	if raw == "" || raw == "null" {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(raw), &compareModels); err != nil {
+		fmt.Printf("Failed to read compare models: %v\n", err)
+	}
+	return compareModels
+}
+
+func renderCompareModels(userRecord *models.Record, c echo.Context, app *pocketbase.PocketBase) error {
+	var params []templates.CompareModelParams
+	for i, target := range loadCompareModels(userRecord) {
+		name := target.Model
+		if apiRecord, err := app.Dao().FindRecordById("apis", target.ApiId); err == nil {
+			name = displayModelName(apiRecord, target.Model)
+		}
+		params = append(params, templates.CompareModelParams{Index: i, Name: name})
+	}
+
+	c.Response().Writer.WriteHeader(200)
+	compareModels := templates.CompareModels(params)
+	err := compareModels.Render(context.Background(), c.Response().Writer)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to render compare models")
+	}
+
+	return nil
+}
+
+func GetCompareModels(c echo.Context, app *pocketbase.PocketBase) error {
+	userRecord, err := app.Dao().FindFirstRecordByData("users", "username", "default")
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to retrieve user record for compare models")
+	}
+
+	return renderCompareModels(userRecord, c, app)
+}
+
+// add the API and model selected in the chat window to the compare list
+func AddCompareModel(c echo.Context, app *pocketbase.PocketBase) error {
+	userRecord, err := app.Dao().FindFirstRecordByData("users", "username", "default")
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to retrieve user record for compare models")
+	}
+
+	target := compareModel{
+		ApiId: userRecord.GetString("selected_api"),
+		Model: userRecord.GetString("selected_model_name"),
+	}
+	compareModels := loadCompareModels(userRecord)
+	if target.ApiId != "" && !slices.Contains(compareModels, target) {
+		userRecord.Set("compare_models", append(compareModels, target))
+		if err := app.Dao().SaveRecord(userRecord); err != nil {
+			return c.String(http.StatusInternalServerError, "failed to update compare models")
+		}
+	}
+
+	return renderCompareModels(userRecord, c, app)
+}
+
+func RemoveCompareModel(index string, c echo.Context, app *pocketbase.PocketBase) error {
+	userRecord, err := app.Dao().FindFirstRecordByData("users", "username", "default")
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to retrieve user record for compare models")
+	}
+
+	compareModels := loadCompareModels(userRecord)
+	i, err := strconv.Atoi(index)
+	if err != nil || i < 0 || i >= len(compareModels) {
+		return c.String(http.StatusBadRequest, "unknown compare model")
+	}
+
+	userRecord.Set("compare_models", slices.Delete(compareModels, i, i+1))
+	if err := app.Dao().SaveRecord(userRecord); err != nil {
+		return c.String(http.StatusInternalServerError, "failed to update compare models")
+	}
+
+	return renderCompareModels(userRecord, c, app)
+}
+
+// every answer to a compare prompt, shown as columns
+func (t *messageTree) compareAnswers(humanId string) []templates.LoadedMessageParams {
+	state := t.messages[humanId].Compare
+
+	var answers []templates.LoadedMessageParams
+	for _, id := range t.children[humanId] {
+		answer := t.messages[id]
+		if answer.Sender != "model" {
+			continue
+		}
+		answer.CompareState = state
+		answers = append(answers, answer)
+	}
+	return answers
+}
+
+// answers that were all kept are sent back to the models together, labelled with the model that wrote them
+func (t *messageTree) combinedAnswers(humanId string) string {
+	var parts []string
+	for _, answer := range t.compareAnswers(humanId) {
+		parts = append(parts, "Answer from "+answer.Model+":\n"+answer.Message)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// save a new message at the end of the active path and stream answers from every compare model side by side
+func compareChatResponse(ctx context.Context, htmxMsg HTMXSocketMsg, socket *chatSocket, app *pocketbase.PocketBase) {
+	threadId := htmxMsg.ThreadId
+
+	userRecord, err := app.Dao().FindFirstRecordByData("users", "username", "default")
+	if err != nil {
+		handleChatError(err, socket, threadId, app)
+		return
+	}
+	compareModels := loadCompareModels(userRecord)
+	if len(compareModels) == 0 {
+		err := errors.New("no models to compare, add them with \"+ current model\" first")
+		handleChatError(err, socket, threadId, app)
+		return
+	}
+
+	chatCollection, err := app.Dao().FindCollectionByNameOrId("chat")
+	if err != nil {
+		handleChatError(err, socket, threadId, app)
+		return
+	}
+
+	tree, err := loadMessageTree(threadId, app)
+	if err != nil {
+		handleChatError(err, socket, threadId, app)
+		return
+	}
+
+	_, _, selectedModelName, err := loadSelectedModel(app)
+	if err != nil {
+		handleChatError(err, socket, threadId, app)
+		return
+	}
+
+	message, promptFields, err := expandPromptCommand(htmxMsg.Msg, htmxMsg.PromptVariables, app)
+	if err != nil {
+		handleChatError(err, socket, threadId, app)
+		return
+	}
+
+	humanData := map[string]any{
+		"thread_id": threadId,
+		"parent_id": tree.activeLeafId(),
+		"message":   message,
+		"sender":    "human",
+		"model":     selectedModelName,
+		"compare":   "open",
+	}
+	maps.Copy(humanData, promptFields)
+	humanRecord, err := saveHumanMessage(htmxMsg.AttachmentDraft, humanData, app)
+	if err != nil {
+		fmt.Printf("Failed to submit user message to chat DB: %v\n", err)
+		handleChatError(err, socket, threadId, app)
+		return
+	}
+
+	// one model message per compare model, all siblings under the prompt
+	var generations []chatGeneration
+	var answers []templates.LoadedMessageParams
+	for _, target := range compareModels {
+		apiRecord, err := app.Dao().FindRecordById("apis", target.ApiId)
+		if err != nil {
+			fmt.Printf("Skipping compare model of a deleted API: %v\n", err)
+			continue
+		}
+		answerModelName := displayModelName(apiRecord, target.Model)
+		params := threadGenerationParams(threadId, apiRecord, app)
+
+		answerRecord := models.NewRecord(chatCollection)
+		answerForm := forms.NewRecordUpsert(app, answerRecord)
+		answerForm.LoadData(map[string]any{
+			"thread_id": threadId,
+			"parent_id": humanRecord.Id,
+			"message":   "",
+			"sender":    "model",
+			"model":     answerModelName,
+		})
+		if err := answerForm.Submit(); err != nil {
+			fmt.Printf("Failed to initialize compare message in chat DB: %v\n", err)
+			handleChatError(err, socket, threadId, app)
+			return
+		}
+
+		generations = append(generations, chatGeneration{
+			ThreadId:      threadId,
+			Record:        answerRecord,
+			ApiRecord:     apiRecord,
+			ModelName:     target.Model,
+			ChatModelName: answerModelName,
+			Params:        params,
+			NoFallbacks:   true,
+		})
+		answers = append(answers, templates.LoadedMessageParams{
+			Id:               answerRecord.Id,
+			Model:            answerModelName,
+			ParentId:         humanRecord.Id,
+			CompareState:     "open",
+			GenerationParams: params,
+		})
+	}
+
+	humanParams := templates.LoadedMessageParams{
+		Id:          humanRecord.Id,
+		Message:     message,
+		ThreadId:    threadId,
+		Attachments: loadAttachments(humanRecord),
+		PromptName:  humanRecord.GetString("prompt_name"),
+	}
+	if err := socket.writeComponent(templates.InitCompareMessage(humanParams, answers)); err != nil {
+		handleChatError(err, socket, threadId, app)
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, generation := range generations {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			// each model gets the history fit into its own context window
+			chatHistory, err := buildChatHistory(threadId, humanRecord.Id, app)
+			if err != nil {
+				handleChatError(err, socket, threadId, app)
+				return
+			}
+			chatHistory.fit(ctx, generation.ApiRecord, generation.ModelName, false, app)
+			generation.History = chatHistory.history()
+			generation.PromptTokens = chatHistory.tokens()
+
+			job := generationJobs.start(generation.ThreadId, generation.Record.Id, socket)
+			streamChatGeneration(ctx, generation, job, app)
+			generationJobs.finish(job)
+		}()
+	}
+	wg.Wait()
+}
+
+// keep one answer in the thread, the others stay reachable as branches
+func KeepCompareAnswer(messageId string, c echo.Context, app *pocketbase.PocketBase) error {
+	answerRecord, err := app.Dao().FindRecordById("chat", messageId)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to find compare answer")
+	}
+	threadId := answerRecord.GetString("thread_id")
+
+	humanRecord, err := app.Dao().FindRecordById("chat", answerRecord.GetString("parent_id"))
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to find compare prompt")
+	}
+
+	if _, err := selectSibling(threadId, humanRecord.Id, messageId, app); err != nil {
+		return c.String(http.StatusInternalServerError, "failed to select compare answer")
+	}
+
+	humanRecord.Set("compare", "")
+	if err := app.Dao().SaveRecord(humanRecord); err != nil {
+		return c.String(http.StatusInternalServerError, "failed to close compare")
+	}
+
+	return renderThread(threadId, "", c, app)
+}
+
+// keep every answer in the thread side by side
+func KeepAllCompareAnswers(humanId string, c echo.Context, app *pocketbase.PocketBase) error {
+	humanRecord, err := app.Dao().FindRecordById("chat", humanId)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to find compare prompt")
+	}
+
+	humanRecord.Set("compare", "all")
+	if err := app.Dao().SaveRecord(humanRecord); err != nil {
+		return c.String(http.StatusInternalServerError, "failed to keep compare answers")
+	}
+
+	return renderThread(humanRecord.GetString("thread_id"), "", c, app)
+}
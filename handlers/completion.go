@@ -0,0 +1,248 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/erikmillergalow/htmx-llmchat/templates"
+
+	openai "github.com/sashabaranov/go-openai"
+
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// how a thread is written out as a single prompt for base models
+type promptFormat struct {
+	Format string
+	// shown in the API editor
+	Name string
+	// a Go template executed with the thread as promptData, the custom format uses the API's own
+	Template string
+	// end of the model's turn, the model would otherwise write the next user message too
+	Stop []string
+}
+
+// the BOS token is left out, servers add it when tokenizing the prompt
+var promptFormats = []promptFormat{
+	{
+		Format: "chatml",
+		Name:   "ChatML",
+		Template: "{{range .Messages}}<|im_start|>{{.Role}}\n{{.Content}}<|im_end|>\n{{end}}" +
+			"<|im_start|>assistant\n",
+		Stop: []string{"<|im_end|>", "<|im_start|>"},
+	},
+	{
+		Format: "llama3",
+		Name:   "Llama 3",
+		Template: "{{range .Messages}}<|start_header_id|>{{.Role}}<|end_header_id|>\n\n{{.Content}}<|eot_id|>{{end}}" +
+			"<|start_header_id|>assistant<|end_header_id|>\n\n",
+		Stop: []string{"<|eot_id|>", "<|start_header_id|>"},
+	},
+	{
+		Format: "alpaca",
+		Name:   "Alpaca",
+		Template: "{{range .Messages}}{{if eq .Role \"system\"}}{{.Content}}\n\n" +
+			"{{else if eq .Role \"assistant\"}}### Response:\n{{.Content}}\n\n" +
+			"{{else}}### Instruction:\n{{.Content}}\n\n{{end}}{{end}}" +
+			"### Response:\n",
+		Stop: []string{"### Instruction:", "### Response:"},
+	},
+	{
+		Format: "custom",
+		Name:   "Custom template",
+	},
+}
+
+func findPromptFormat(format string) (promptFormat, bool) {
+	for _, promptFormat := range promptFormats {
+		if promptFormat.Format == format {
+			return promptFormat, true
+		}
+	}
+	return promptFormat{}, false
+}
+
+// choices for the prompt format select of the API editor
+func promptFormatOptions() []templates.PromptFormatParams {
+	var options []templates.PromptFormatParams
+	for _, promptFormat := range promptFormats {
+		options = append(options, templates.PromptFormatParams{Format: promptFormat.Format, Name: promptFormat.Name})
+	}
+	return options
+}
+
+type promptMessage struct {
+	Role    string
+	Content string
+}
+
+// what prompt templates are executed with
+type promptData struct {
+	Messages []promptMessage
+}
+
+// text of a message, images can't be part of a plain prompt
+func promptContent(message openai.ChatCompletionMessage) string {
+	if len(message.MultiContent) == 0 {
+		return message.Content
+	}
+	var text []string
+	for _, part := range message.MultiContent {
+		if part.Type == openai.ChatMessagePartTypeText {
+			text = append(text, part.Text)
+		}
+	}
+	return strings.Join(text, "\n")
+}
+
+// the thread as one prompt and the stop sequences of its format
+// custom templates can list their own stop sequences, one per line, in a "stop" template
+func renderPrompt(apiRecord *models.Record, messages []openai.ChatCompletionMessage) (string, []string, error) {
+	format, ok := findPromptFormat(apiRecord.GetString("prompt_format"))
+	if !ok {
+		return "", nil, fmt.Errorf("unknown prompt format %q", apiRecord.GetString("prompt_format"))
+	}
+	source := format.Template
+	if source == "" {
+		source = apiRecord.GetString("prompt_template")
+	}
+	if strings.TrimSpace(source) == "" {
+		return "", nil, fmt.Errorf("prompt format %q has no template", format.Format)
+	}
+
+	promptTemplate, err := template.New("prompt").Parse(source)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid prompt template: %w", err)
+	}
+
+	var data promptData
+	for _, message := range messages {
+		data.Messages = append(data.Messages, promptMessage{Role: message.Role, Content: promptContent(message)})
+	}
+
+	var prompt strings.Builder
+	if err := promptTemplate.Execute(&prompt, data); err != nil {
+		return "", nil, fmt.Errorf("failed to render prompt template: %w", err)
+	}
+
+	stop := slices.Clone(format.Stop)
+	if stopTemplate := promptTemplate.Lookup("stop"); stopTemplate != nil {
+		var stopLines strings.Builder
+		if err := stopTemplate.Execute(&stopLines, data); err != nil {
+			return "", nil, fmt.Errorf("failed to render stop sequences: %w", err)
+		}
+		for _, line := range strings.Split(stopLines.String(), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				stop = append(stop, line)
+			}
+		}
+	}
+
+	return prompt.String(), stop, nil
+}
+
+// tools are left out, and go-openai's completion request has no seed
+func (p *openAIProvider) completionRequest(req openai.ChatCompletionRequest) (openai.CompletionRequest, error) {
+	prompt, stop, err := renderPrompt(p.apiRecord, req.Messages)
+	if err != nil {
+		return openai.CompletionRequest{}, err
+	}
+
+	completionReq := openai.CompletionRequest{
+		Model:            req.Model,
+		Prompt:           prompt,
+		MaxTokens:        req.MaxTokens,
+		Temperature:      req.Temperature,
+		TopP:             req.TopP,
+		PresencePenalty:  req.PresencePenalty,
+		FrequencyPenalty: req.FrequencyPenalty,
+		Stop:             slices.Concat(stop, req.Stop),
+	}
+	// OpenAI style servers stop after 16 tokens unless told otherwise
+	if completionReq.MaxTokens == 0 {
+		completionReq.MaxTokens = defaultCompletionTokens
+	}
+	return completionReq, nil
+}
+
+const defaultCompletionTokens = 2048
+
+// finish reasons of both endpoints are the same strings
+func completionChoice(choice openai.CompletionChoice) openai.ChatCompletionStreamChoice {
+	return openai.ChatCompletionStreamChoice{
+		Delta:        openai.ChatCompletionStreamChoiceDelta{Content: choice.Text},
+		FinishReason: openai.FinishReason(choice.FinishReason),
+	}
+}
+
+func (p *openAIProvider) streamCompletion(ctx context.Context, req openai.ChatCompletionRequest) (ChatStream, error) {
+	completionReq, err := p.completionRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	completionReq.Stream = true
+
+	usage := &streamUsage{}
+	stream, err := p.client(usage, nil).CreateCompletionStream(ctx, completionReq)
+	if err != nil {
+		return nil, err
+	}
+	return &completionStream{CompletionStream: stream, usage: usage}, nil
+}
+
+func (p *openAIProvider) completion(ctx context.Context, req openai.ChatCompletionRequest) (openai.ChatCompletionResponse, error) {
+	completionReq, err := p.completionRequest(req)
+	if err != nil {
+		return openai.ChatCompletionResponse{}, err
+	}
+
+	response, err := p.client(nil, nil).CreateCompletion(ctx, completionReq)
+	if err != nil {
+		return openai.ChatCompletionResponse{}, err
+	}
+	if len(response.Choices) == 0 {
+		return openai.ChatCompletionResponse{}, errors.New("completion response has no choices")
+	}
+
+	choice := completionChoice(response.Choices[0])
+	return openai.ChatCompletionResponse{
+		Model: response.Model,
+		Choices: []openai.ChatCompletionChoice{{
+			Message:      openai.ChatCompletionMessage{Role: openai.ChatMessageRoleAssistant, Content: choice.Delta.Content},
+			FinishReason: choice.FinishReason,
+		}},
+		Usage: response.Usage,
+	}, nil
+}
+
+// completion chunks passed on as chat chunks
+type completionStream struct {
+	*openai.CompletionStream
+	usage *streamUsage
+}
+
+func (s *completionStream) Recv() (openai.ChatCompletionStreamResponse, error) {
+	response, err := s.CompletionStream.Recv()
+	if err != nil {
+		return openai.ChatCompletionStreamResponse{}, err
+	}
+
+	var choices []openai.ChatCompletionStreamChoice
+	for _, choice := range response.Choices {
+		choices = append(choices, completionChoice(choice))
+	}
+	return openai.ChatCompletionStreamResponse{ID: response.ID, Model: response.Model, Choices: choices}, nil
+}
+
+func (s *completionStream) Usage() *openai.Usage {
+	return s.usage.get()
+}
+
+// completions have no thinking field, <think> tags stay in the content for the chat stream to split out
+func (s *completionStream) Reasoning() string {
+	return ""
+}
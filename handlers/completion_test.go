@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+	openai "github.com/sashabaranov/go-openai"
+)
+
+func TestRenderPrompt(t *testing.T) {
+	messages := []openai.ChatCompletionMessage{
+		{Role: openai.ChatMessageRoleSystem, Content: "be brief"},
+		{Role: openai.ChatMessageRoleUser, Content: "hi"},
+	}
+
+	apiRecord := models.NewRecord(&models.Collection{Name: "apis"})
+	apiRecord.Set("prompt_format", "chatml")
+	prompt, stop, err := renderPrompt(apiRecord, messages)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "<|im_start|>system\nbe brief<|im_end|>\n<|im_start|>user\nhi<|im_end|>\n<|im_start|>assistant\n"
+	if prompt != want {
+		t.Errorf("prompt = %q, want %q", prompt, want)
+	}
+	if len(stop) != 2 {
+		t.Errorf("stop = %q, want the ChatML stop sequences", stop)
+	}
+
+	apiRecord.Set("prompt_format", "custom")
+	apiRecord.Set("prompt_template", "{{range .Messages}}{{.Content}}\n{{end}}{{define \"stop\"}}END{{end}}")
+	prompt, stop, err = renderPrompt(apiRecord, messages)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prompt != "be brief\nhi\n" || len(stop) != 1 || stop[0] != "END" {
+		t.Errorf("custom prompt = %q with stop %q", prompt, stop)
+	}
+}
+
+func TestRenderPromptEmptyCustomTemplate(t *testing.T) {
+	apiRecord := models.NewRecord(&models.Collection{Name: "apis"})
+	apiRecord.Set("prompt_format", "custom")
+	apiRecord.Set("prompt_template", "  \n")
+	if prompt, _, err := renderPrompt(apiRecord, nil); err == nil {
+		t.Errorf("an empty custom template should fail, got prompt %q", prompt)
+	}
+}
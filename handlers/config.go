@@ -8,6 +8,7 @@ import (
 	"github.com/erikmillergalow/htmx-llmchat/templates"
 
 	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase"
 )
 
@@ -20,9 +21,17 @@ func OpenConfig(c echo.Context, app *pocketbase.PocketBase) error {
 		All(&settings)
 
 
+	// choices for the APIs that write thread titles and embed messages
+	var apiParams []templates.ApiParams
+	app.Dao().DB().
+		Select("*").
+		From("apis").
+		OrderBy("name ASC").
+		All(&apiParams)
+
 	c.Response().Header().Set("HX-Trigger-After-Settle", "config-opened")
 	c.Response().Writer.WriteHeader(200)
-	loadedSettingsMenu := templates.SideBarMenu(settings[0])
+	loadedSettingsMenu := templates.SideBarMenu(settings[0], apiParams, embeddingIndexStatus(app))
 	err := loadedSettingsMenu.Render(context.Background(), c.Response().Writer)
 	if err != nil {
 		return c.String(http.StatusInternalServerError, "failed to render loaded chat response")
@@ -31,20 +40,80 @@ func OpenConfig(c echo.Context, app *pocketbase.PocketBase) error {
 	return nil
 }
 
+// fetch the system prompt used by threads that don't define their own
+func loadDefaultSystemPrompt(app *pocketbase.PocketBase) string {
+	settingsRecord, err := app.Dao().FindFirstRecordByData("settings", "type", "keys")
+	if err != nil {
+		return ""
+	}
+
+	return settingsRecord.GetString("system_prompt")
+}
+
+func SaveDefaultSystemPrompt(prompt string, c echo.Context, app *pocketbase.PocketBase) error {
+	settingsRecord, err := app.Dao().FindFirstRecordByData("settings", "type", "keys")
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to find settings record")
+	}
+
+	settingsRecord.Set("system_prompt", prompt)
+	if err := app.Dao().SaveRecord(settingsRecord); err != nil {
+		return c.String(http.StatusInternalServerError, "failed to update default system prompt")
+	}
+
+	c.Response().Writer.WriteHeader(200)
+	settingsUpdated := templates.SettingsUpdated()
+	err = settingsUpdated.Render(context.Background(), c.Response().Writer)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to render settings update response")
+	}
+
+	return nil
+}
+
 func GetModelStats(c echo.Context, app *pocketbase.PocketBase) error {
 	var messages []templates.LoadedMessageParams
 	app.Dao().DB().
 		Select("*").
 		From("chat").
+		Where(dbx.NewExp("draft = FALSE")).
+		AndWhere(dbx.NewExp("sender != 'tool'")).
 		All(&messages)
 
 	totalMessages := make(map[string]int)
 	usefulMessages := make(map[string]int)
+	tokenUsage := make(map[string]templates.TokenUsageParams)
+	ttfts := make(map[string][]int)
+	durations := make(map[string][]int)
+	rates := make(map[string][]float64)
 	for _, message := range messages {
 		totalMessages[message.Model]++
 		if message.Useful {
 			usefulMessages[message.Model]++
 		}
+
+		usage := tokenUsage[message.Model]
+		usage.PromptTokens += message.PromptTokens
+		usage.CompletionTokens += message.CompletionTokens
+		usage.TotalTokens += message.TotalTokens
+		usage.Estimated = usage.Estimated || message.UsageEstimated
+		tokenUsage[message.Model] = usage
+
+		// only responses that were timed
+		if message.DurationMs > 0 {
+			durations[message.Model] = append(durations[message.Model], message.DurationMs)
+			if message.TTFTMs > 0 {
+				ttfts[message.Model] = append(ttfts[message.Model], message.TTFTMs)
+			}
+			if message.TokensPerSecond > 0 {
+				rates[message.Model] = append(rates[message.Model], message.TokensPerSecond)
+			}
+		}
+	}
+
+	latency := make(map[string]templates.LatencyParams)
+	for model := range durations {
+		latency[model] = latencyStats(ttfts[model], durations[model], rates[model])
 	}
 
 	var sortedKeys []string
@@ -63,7 +132,7 @@ func GetModelStats(c echo.Context, app *pocketbase.PocketBase) error {
 	})
 		
 	c.Response().Writer.WriteHeader(200)
-	modelStatsViewer := templates.ModelStatsViewer(sortedKeys, totalMessages, usefulMessages, percent)
+	modelStatsViewer := templates.ModelStatsViewer(sortedKeys, totalMessages, usefulMessages, percent, tokenUsage, latency)
 	err := modelStatsViewer.Render(context.Background(), c.Response().Writer)
 	if err != nil {
 		return c.String(http.StatusInternalServerError, "failed to render settings update response")
@@ -0,0 +1,438 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/erikmillergalow/htmx-llmchat/templates"
+
+	"github.com/labstack/echo/v5"
+	openai "github.com/sashabaranov/go-openai"
+
+	"github.com/pocketbase/pocketbase"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// ways of fitting a thread that has outgrown the model's context window
+const (
+	contextDropOldest    = "drop-oldest"
+	contextKeepFirstLast = "keep-first-last"
+	contextSummarize     = "summarize"
+)
+
+// number of recent messages kept by the keep-first-last strategy unless the thread sets its own
+const defaultContextKeep = 4
+
+// chat formats add a few tokens around every message and to prime the reply
+const (
+	messageTokenOverhead = 4
+	requestTokenOverhead = 3
+)
+
+// rough local estimate of the tokens a BPE tokenizer produces for some text
+// words cost about a token per four characters, punctuation and CJK characters about one each
+func estimateTokens(text string) int {
+	tokens := 0
+	wordLength := 0
+	endWord := func() {
+		tokens += (wordLength + 3) / 4
+		wordLength = 0
+	}
+
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Han, r) || unicode.In(r, unicode.Hiragana, unicode.Katakana, unicode.Hangul):
+			endWord()
+			tokens++
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			wordLength++
+		case unicode.IsSpace(r):
+			endWord()
+		default:
+			endWord()
+			tokens++
+		}
+	}
+	endWord()
+
+	return tokens
+}
+
+// a message that can be sent as context along with its estimated size
+type contextMessage struct {
+	Id      string
+	Message openai.ChatCompletionMessage
+	Tokens  int
+}
+
+func newContextMessage(id string, role string, content string) contextMessage {
+	return contextMessage{
+		Id: id,
+		Message: openai.ChatCompletionMessage{
+			Role:    role,
+			Content: content,
+		},
+		Tokens: estimateTokens(content) + messageTokenOverhead,
+	}
+}
+
+// the history sent with a request, after it has been fit into the context window
+type chatContext struct {
+	ThreadRecord *models.Record
+	// system prompt and summary, always sent
+	System []contextMessage
+	// conversation, oldest first
+	Messages []contextMessage
+	// ids of messages left out to fit the context window
+	Omitted    []string
+	Summarized bool
+	Limit      int
+}
+
+func (c *chatContext) history() []openai.ChatCompletionMessage {
+	var history []openai.ChatCompletionMessage
+	for _, message := range c.System {
+		history = append(history, message.Message)
+	}
+	for _, message := range c.Messages {
+		history = append(history, message.Message)
+	}
+	return history
+}
+
+func (c *chatContext) tokens() int {
+	tokens := requestTokenOverhead
+	for _, message := range c.System {
+		tokens += message.Tokens
+	}
+	for _, message := range c.Messages {
+		tokens += message.Tokens
+	}
+	return tokens
+}
+
+func threadContextStrategy(threadRecord *models.Record) (string, int) {
+	strategy := threadRecord.GetString("context_strategy")
+	if !slices.Contains([]string{contextDropOldest, contextKeepFirstLast, contextSummarize}, strategy) {
+		strategy = contextDropOldest
+	}
+
+	keep := threadRecord.GetInt("context_keep")
+	if keep < 1 {
+		keep = defaultContextKeep
+	}
+
+	return strategy, keep
+}
+
+// tokens left free for the model's reply, max_tokens when it is set
+func responseTokenReserve(limit int, params templates.GenerationParams) int {
+	if params.MaxTokens != nil {
+		return *params.MaxTokens
+	}
+	return min(1024, limit/4)
+}
+
+// tokens set aside for the summary of older messages
+func summaryTokenBudget(limit int) int {
+	return min(512, limit/8)
+}
+
+// order in which messages are left out, the message being answered is always sent
+func contextDropOrder(strategy string, count int, keep int) []int {
+	last := count - 1
+	var order []int
+
+	// the opening exchange usually sets up the task, so it goes after the middle and the recent messages
+	if strategy == contextKeepFirstLast {
+		first := min(2, last)
+		recent := max(last+1-keep, first)
+		for i := first; i < recent; i++ {
+			order = append(order, i)
+		}
+		for i := recent; i < last; i++ {
+			order = append(order, i)
+		}
+		for i := 0; i < first; i++ {
+			order = append(order, i)
+		}
+		return order
+	}
+
+	for i := 0; i < last; i++ {
+		order = append(order, i)
+	}
+	return order
+}
+
+// leave messages out until the request fits in the context window of the model
+// preview only estimates a missing summary, otherwise older messages are summarized by the model
+func (c *chatContext) fit(ctx context.Context, apiRecord *models.Record, modelName string, preview bool, app *pocketbase.PocketBase) {
+	if apiRecord != nil {
+		c.Limit = contextWindowLimit(apiRecord, modelName)
+	}
+	if c.Limit == 0 || len(c.Messages) == 0 {
+		return
+	}
+
+	strategy, keep := threadContextStrategy(c.ThreadRecord)
+
+	reserve := responseTokenReserve(c.Limit, resolveGenerationParams(c.ThreadRecord, apiRecord))
+	available := c.Limit - reserve - requestTokenOverhead
+	for _, message := range c.System {
+		available -= message.Tokens
+	}
+	if strategy == contextSummarize {
+		available -= summaryTokenBudget(c.Limit)
+	}
+
+	used := 0
+	for _, message := range c.Messages {
+		used += message.Tokens
+	}
+	if used <= available {
+		return
+	}
+
+	// the calls whose results are being answered are sent along with them
+	answering := len(c.Messages) - 1
+	for answering > 0 && c.Messages[answering].Message.Role == openai.ChatMessageRoleTool {
+		answering--
+	}
+
+	omitted := make(map[int]bool)
+	order := contextDropOrder(strategy, len(c.Messages), keep)
+	for _, i := range order {
+		if used <= available {
+			break
+		}
+		if i >= answering {
+			continue
+		}
+		omitted[i] = true
+		used -= c.Messages[i].Tokens
+	}
+	c.pairToolCalls(omitted)
+
+	// a reply without the message it answers is confusing, leave it out too
+	if strategy != contextKeepFirstLast {
+		for i, message := range c.Messages {
+			if omitted[i] {
+				continue
+			}
+			if message.Message.Role != openai.ChatMessageRoleAssistant && message.Message.Role != openai.ChatMessageRoleTool {
+				break
+			}
+			omitted[i] = true
+		}
+	}
+
+	var kept []contextMessage
+	var dropped []contextMessage
+	for i, message := range c.Messages {
+		if omitted[i] {
+			dropped = append(dropped, message)
+			c.Omitted = append(c.Omitted, message.Id)
+		} else {
+			kept = append(kept, message)
+		}
+	}
+	c.Messages = kept
+
+	if strategy != contextSummarize || len(dropped) == 0 {
+		return
+	}
+
+	summary, err := summarizeOmittedMessages(ctx, c.ThreadRecord, dropped, apiRecord, modelName, preview, app)
+	if err != nil {
+		fmt.Printf("Failed to summarize older messages, leaving them out: %v\n", err)
+		return
+	}
+
+	summaryMessage := newContextMessage("", openai.ChatMessageRoleSystem, "Summary of the earlier conversation:\n"+summary)
+	if preview && summary == "" {
+		summaryMessage.Tokens = summaryTokenBudget(c.Limit)
+	}
+	c.System = append(c.System, summaryMessage)
+	c.Summarized = true
+}
+
+// APIs reject tool results without the call that asked for them and calls without results
+// a call and its results are left out together
+func (c *chatContext) pairToolCalls(omitted map[int]bool) {
+	for start := 0; start < len(c.Messages); start++ {
+		if len(c.Messages[start].Message.ToolCalls) == 0 {
+			continue
+		}
+		end := start + 1
+		for end < len(c.Messages) && c.Messages[end].Message.Role == openai.ChatMessageRoleTool {
+			end++
+		}
+
+		dropped := false
+		for i := start; i < end; i++ {
+			dropped = dropped || omitted[i]
+		}
+		for i := start; i < end && dropped; i++ {
+			omitted[i] = true
+		}
+		start = end - 1
+	}
+}
+
+// summarize the messages left out of the context, building on the stored summary where possible
+// the summary is stored on the thread with the id of the last message it covers
+// preview returns the stored summary if it is current, or an empty string if it would be rewritten
+func summarizeOmittedMessages(ctx context.Context, threadRecord *models.Record, omitted []contextMessage, apiRecord *models.Record, modelName string, preview bool, app *pocketbase.PocketBase) (string, error) {
+	previousSummary := threadRecord.GetString("context_summary")
+	summaryOf := threadRecord.GetString("context_summary_of")
+
+	// the path up to a message is unique in the tree, so the last id identifies everything summarized
+	lastId := omitted[len(omitted)-1].Id
+	if previousSummary != "" && summaryOf == lastId {
+		return previousSummary, nil
+	}
+	if preview {
+		return "", nil
+	}
+
+	unsummarized := omitted
+	if index := slices.IndexFunc(omitted, func(m contextMessage) bool { return m.Id == summaryOf }); index >= 0 && previousSummary != "" {
+		unsummarized = omitted[index+1:]
+	} else {
+		previousSummary = ""
+	}
+
+	var transcript strings.Builder
+	if previousSummary != "" {
+		transcript.WriteString("Summary so far:\n" + previousSummary + "\n\nConversation continued:\n")
+	}
+	for _, message := range unsummarized {
+		transcript.WriteString(message.Message.Role + ": " + messageText(message.Message) + "\n\n")
+	}
+
+	release, err := apiLimits.acquire(ctx, apiRecord, nil)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
+	provider, err := newProvider(apiRecord)
+	if err != nil {
+		return "", err
+	}
+	response, err := provider.Chat(ctx, openai.ChatCompletionRequest{
+		Model: modelName,
+		Messages: []openai.ChatCompletionMessage{
+			{
+				Role:    openai.ChatMessageRoleSystem,
+				Content: "Summarize the conversation below so it can replace the original messages as context. Keep facts, decisions, names, numbers and code identifiers. Reply with the summary only.",
+			},
+			{
+				Role:    openai.ChatMessageRoleUser,
+				Content: transcript.String(),
+			},
+		},
+		MaxTokens: summaryTokenBudget(contextWindowLimit(apiRecord, modelName)),
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(response.Choices) == 0 {
+		return "", fmt.Errorf("summary response had no choices")
+	}
+	summary, _ := splitReasoning(response.Choices[0].Message.Content, opensThinkBlock(apiRecord))
+	summary = strings.TrimSpace(summary)
+
+	threadRecord.Set("context_summary", summary)
+	threadRecord.Set("context_summary_of", lastId)
+	if err := app.Dao().SaveRecord(threadRecord); err != nil {
+		fmt.Printf("Failed to store context summary: %v\n", err)
+	}
+
+	return summary, nil
+}
+
+// estimate the next request of a thread and mark which messages of the active path it leaves out
+func previewThreadContext(threadId string, app *pocketbase.PocketBase) (templates.ContextUsageParams, []templates.LoadedMessageParams, error) {
+	tree, err := loadMessageTree(threadId, app)
+	if err != nil {
+		return templates.ContextUsageParams{}, nil, err
+	}
+	messages := tree.activePath()
+
+	chatContext, err := buildChatHistory(threadId, tree.activeLeafId(), app)
+	if err != nil {
+		return templates.ContextUsageParams{}, nil, err
+	}
+
+	// without a selected model only the size is shown
+	apiRecord, modelName, _, err := loadSelectedModel(app)
+	if err != nil {
+		apiRecord = nil
+	}
+	chatContext.fit(context.Background(), apiRecord, modelName, true, app)
+
+	status := "omitted"
+	if chatContext.Summarized {
+		status = "summarized"
+	}
+	for i := range messages {
+		if slices.Contains(chatContext.Omitted, messages[i].Id) {
+			messages[i].ContextStatus = status
+		}
+	}
+
+	strategy, keep := threadContextStrategy(chatContext.ThreadRecord)
+	usage := templates.ContextUsageParams{
+		ThreadId:   threadId,
+		Tokens:     chatContext.tokens(),
+		Limit:      chatContext.Limit,
+		Omitted:    len(chatContext.Omitted),
+		Summarized: chatContext.Summarized,
+		Strategy:   strategy,
+		Keep:       keep,
+	}
+
+	return usage, messages, nil
+}
+
+func SaveThreadContextSettings(id string, strategy string, keep string, c echo.Context, app *pocketbase.PocketBase) error {
+	threadRecord, err := app.Dao().FindRecordById("chat_meta", id)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to find thread record to set context strategy")
+	}
+
+	if !slices.Contains([]string{contextDropOldest, contextKeepFirstLast, contextSummarize}, strategy) {
+		return c.String(http.StatusBadRequest, "unknown context strategy")
+	}
+	keepCount, err := strconv.Atoi(keep)
+	if err != nil || keepCount < 1 {
+		keepCount = defaultContextKeep
+	}
+
+	threadRecord.Set("context_strategy", strategy)
+	threadRecord.Set("context_keep", keepCount)
+	if err := app.Dao().SaveRecord(threadRecord); err != nil {
+		return c.String(http.StatusInternalServerError, "failed to update thread context strategy")
+	}
+
+	usage, messages, err := previewThreadContext(id, app)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to estimate thread context")
+	}
+
+	c.Response().Writer.WriteHeader(200)
+	contextUpdate := templates.ContextUpdate(usage, messages)
+	err = contextUpdate.Render(context.Background(), c.Response().Writer)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to render context update")
+	}
+
+	return nil
+}
@@ -0,0 +1,454 @@
+package handlers
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/erikmillergalow/htmx-llmchat/templates"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+const (
+	// messages read per pass of the indexer
+	embeddingBatchMessages = 16
+	// passages sent per embeddings request
+	embeddingBatchPassages = 32
+	// how long a passage grows before a new one is started, and how long a paragraph can be before it is cut
+	passageLength    = 1000
+	maxPassageLength = 1500
+	// new and edited messages are also picked up when nothing wakes the indexer
+	embeddingIndexInterval  = time.Minute
+	embeddingRequestTimeout = time.Minute
+)
+
+var errEmbeddingsNotConfigured = errors.New("no embedding API and model set")
+
+// the API answered without a usable vector for every input
+var errMissingEmbedding = errors.New("missing embedding")
+
+// embeds chat messages in the background, from the oldest unembedded one on
+type embeddingIndexer struct {
+	wake chan struct{}
+}
+
+var messageEmbeddings = &embeddingIndexer{wake: make(chan struct{}, 1)}
+
+// index new messages now rather than at the next interval
+func (i *embeddingIndexer) nudge() {
+	select {
+	case i.wake <- struct{}{}:
+	default:
+	}
+}
+
+// existing messages are indexed on the first pass, later passes only embed new and edited ones
+func StartEmbeddingIndexer(app *pocketbase.PocketBase) {
+	go func() {
+		for {
+			if err := indexMessageEmbeddings(app); err != nil && !errors.Is(err, errEmbeddingsNotConfigured) {
+				fmt.Printf("Failed to index message embeddings: %v\n", err)
+			}
+			select {
+			case <-messageEmbeddings.wake:
+			case <-time.After(embeddingIndexInterval):
+			}
+		}
+	}()
+}
+
+// the API and model messages are embedded with, and the key their passages are stored under
+func embeddingSettings(app *pocketbase.PocketBase) (*models.Record, string, string, error) {
+	settingsRecord, err := app.Dao().FindFirstRecordByData("settings", "type", "keys")
+	if err != nil {
+		return nil, "", "", err
+	}
+	apiId, model := settingsRecord.GetString("embedding_api"), settingsRecord.GetString("embedding_model")
+	if apiId == "" || model == "" {
+		return nil, "", "", errEmbeddingsNotConfigured
+	}
+
+	apiRecord, err := app.Dao().FindRecordById("apis", apiId)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("failed to find embedding API: %w", err)
+	}
+	return apiRecord, model, apiId + ":" + model, nil
+}
+
+// embed texts with the configured API, each vector scaled to unit length so cosine similarity is a dot product
+func embedTexts(ctx context.Context, apiRecord *models.Record, model string, input []string) ([][]float32, error) {
+	provider, err := newProvider(apiRecord)
+	if err != nil {
+		return nil, err
+	}
+	embedder, ok := provider.(EmbeddingProvider)
+	if !ok {
+		return nil, fmt.Errorf("API %s can't embed text", apiRecord.GetString("name"))
+	}
+
+	release, err := apiLimits.acquire(ctx, apiRecord, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	vectors, err := embedder.Embed(ctx, model, input)
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) != len(input) {
+		return nil, fmt.Errorf("%w: got %d embeddings for %d inputs", errMissingEmbedding, len(vectors), len(input))
+	}
+	for i, vector := range vectors {
+		if len(vector) == 0 {
+			return nil, fmt.Errorf("%w: embedding %d is empty", errMissingEmbedding, i)
+		}
+		normalizeVector(vector)
+	}
+	return vectors, nil
+}
+
+// transient failures are retried with backoff like chat requests, anything else is returned right away
+func embedTextsWithRetries(apiRecord *models.Record, model string, input []string) ([][]float32, error) {
+	for retry := 0; ; retry++ {
+		ctx, hint := withRetryHint(context.Background())
+		ctx, cancel := context.WithTimeout(ctx, embeddingRequestTimeout)
+		vectors, err := embedTexts(ctx, apiRecord, model, input)
+		cancel()
+		if err == nil || !retryableError(err) || retry >= maxRetries {
+			return vectors, err
+		}
+
+		delay, worthWaiting := retryDelay(retry, hint.get())
+		if !worthWaiting {
+			return nil, err
+		}
+		fmt.Printf("Retrying embeddings in %s: %v\n", delay, err)
+		time.Sleep(delay)
+	}
+}
+
+// the API turned down the input itself, too long or refused, so sending it again won't help
+// anything else, like a wrong key or an unreachable server, holds up every message alike
+func rejectedInput(err error) bool {
+	switch errorStatus(err) {
+	case http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusUnprocessableEntity:
+		return true
+	}
+	return errors.Is(err, errMissingEmbedding)
+}
+
+func normalizeVector(vector []float32) {
+	var sum float64
+	for _, value := range vector {
+		sum += float64(value) * float64(value)
+	}
+	if sum == 0 {
+		return
+	}
+	scale := float32(1 / math.Sqrt(sum))
+	for i := range vector {
+		vector[i] *= scale
+	}
+}
+
+func encodeVector(vector []float32) string {
+	data := make([]byte, 4*len(vector))
+	for i, value := range vector {
+		binary.LittleEndian.PutUint32(data[4*i:], math.Float32bits(value))
+	}
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+func decodeVector(encoded string) ([]float32, error) {
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	vector := make([]float32, len(data)/4)
+	for i := range vector {
+		vector[i] = math.Float32frombits(binary.LittleEndian.Uint32(data[4*i:]))
+	}
+	return vector, nil
+}
+
+func dotProduct(a []float32, b []float32) float64 {
+	var sum float64
+	for i := range a {
+		sum += float64(a[i]) * float64(b[i])
+	}
+	return sum
+}
+
+// split a message into passages of a few paragraphs, so a match points at the part of a long answer it came from
+func splitPassages(text string) []string {
+	var paragraphs []string
+	for _, paragraph := range strings.Split(text, "\n\n") {
+		paragraph = strings.TrimSpace(paragraph)
+		for len(paragraph) > maxPassageLength {
+			cut := strings.LastIndexAny(paragraph[:passageLength], " \n")
+			if cut <= 0 {
+				// text without spaces, like Chinese or Japanese, is cut between characters
+				cut = passageLength
+				for cut > 0 && !utf8.RuneStart(paragraph[cut]) {
+					cut--
+				}
+			}
+			paragraphs = append(paragraphs, strings.TrimSpace(paragraph[:cut]))
+			paragraph = strings.TrimSpace(paragraph[cut:])
+		}
+		if paragraph != "" {
+			paragraphs = append(paragraphs, paragraph)
+		}
+	}
+
+	var passages []string
+	current := ""
+	for _, paragraph := range paragraphs {
+		if current != "" && len(current)+len(paragraph) > passageLength {
+			passages = append(passages, current)
+			current = ""
+		}
+		if current != "" {
+			current += "\n\n"
+		}
+		current += paragraph
+	}
+	if current != "" {
+		passages = append(passages, current)
+	}
+	return passages
+}
+
+type pendingEmbedding struct {
+	Id       string `db:"id"`
+	ThreadId string `db:"thread_id"`
+	Message  string `db:"message"`
+	Updated  string `db:"updated"`
+}
+
+// embed every message without passages for the current model, or embedded before it was last changed
+func indexMessageEmbeddings(app *pocketbase.PocketBase) error {
+	apiRecord, model, key, err := embeddingSettings(app)
+	if err != nil {
+		return err
+	}
+
+	// passages of another model, or of deleted messages, are never searched
+	for _, table := range []string{"message_embeddings", "embedding_failures"} {
+		if _, err := app.Dao().DB().
+			NewQuery("DELETE FROM " + table + " WHERE model != {:model} OR message_id NOT IN (SELECT id FROM chat)").
+			Bind(dbx.Params{"model": key}).
+			Execute(); err != nil {
+			return err
+		}
+	}
+
+	for {
+		// responses still streaming are embedded once they are saved
+		var generating []any
+		for _, messageId := range generationJobs.messageIds() {
+			generating = append(generating, messageId)
+		}
+
+		var pending []pendingEmbedding
+		err := app.Dao().DB().
+			Select("id", "thread_id", "message", "updated").
+			From("chat").
+			Where(dbx.NewExp("draft = FALSE")).
+			AndWhere(dbx.In("sender", "human", "model")).
+			AndWhere(dbx.NewExp("TRIM(message) != ''")).
+			AndWhere(dbx.NotIn("id", generating...)).
+			AndWhere(dbx.NewExp(
+				"NOT EXISTS (SELECT 1 FROM message_embeddings e WHERE e.message_id = chat.id AND e.model = {:model} AND e.message_updated = chat.updated)",
+				dbx.Params{"model": key},
+			)).
+			// messages the API turned down are tried again once they are edited
+			AndWhere(dbx.NewExp(
+				"NOT EXISTS (SELECT 1 FROM embedding_failures f WHERE f.message_id = chat.id AND f.model = {:model} AND f.message_updated = chat.updated)",
+				dbx.Params{"model": key},
+			)).
+			OrderBy("created ASC").
+			Limit(embeddingBatchMessages).
+			All(&pending)
+		if err != nil {
+			return err
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+
+		err = embedMessages(app, apiRecord, model, key, pending)
+		if err == nil {
+			continue
+		}
+		if !rejectedInput(err) {
+			return err
+		}
+
+		// one message the API turns down shouldn't hold back the rest of the batch
+		for _, message := range pending {
+			if len(pending) > 1 {
+				err = embedMessages(app, apiRecord, model, key, []pendingEmbedding{message})
+			}
+			if err == nil {
+				continue
+			}
+			if !rejectedInput(err) {
+				return err
+			}
+			fmt.Printf("Skipping message %s for embeddings: %v\n", message.Id, err)
+			if err := saveEmbeddingFailure(app, key, message, err); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func saveEmbeddingFailure(app *pocketbase.PocketBase, key string, message pendingEmbedding, embedErr error) error {
+	collection, err := app.Dao().FindCollectionByNameOrId("embedding_failures")
+	if err != nil {
+		return err
+	}
+
+	return app.Dao().RunInTransaction(func(txDao *daos.Dao) error {
+		if _, err := txDao.DB().Delete("embedding_failures", dbx.HashExp{"message_id": message.Id}).Execute(); err != nil {
+			return err
+		}
+		record := models.NewRecord(collection)
+		record.Set("message_id", message.Id)
+		record.Set("model", key)
+		record.Set("message_updated", message.Updated)
+		record.Set("error", embedErr.Error())
+		return txDao.SaveRecord(record)
+	})
+}
+
+func embedMessages(app *pocketbase.PocketBase, apiRecord *models.Record, model string, key string, messages []pendingEmbedding) error {
+	type passage struct {
+		message pendingEmbedding
+		index   int
+		text    string
+	}
+	var passages []passage
+	for _, message := range messages {
+		for i, text := range splitPassages(message.Message) {
+			passages = append(passages, passage{message: message, index: i, text: text})
+		}
+	}
+
+	vectors := make([][]float32, 0, len(passages))
+	for start := 0; start < len(passages); start += embeddingBatchPassages {
+		var input []string
+		for _, passage := range passages[start:min(start+embeddingBatchPassages, len(passages))] {
+			input = append(input, passage.text)
+		}
+
+		batch, err := embedTextsWithRetries(apiRecord, model, input)
+		if err != nil {
+			return err
+		}
+		vectors = append(vectors, batch...)
+	}
+
+	collection, err := app.Dao().FindCollectionByNameOrId("message_embeddings")
+	if err != nil {
+		return err
+	}
+
+	// passages of an edited message are replaced all at once
+	return app.Dao().RunInTransaction(func(txDao *daos.Dao) error {
+		for _, message := range messages {
+			if _, err := txDao.DB().Delete("message_embeddings", dbx.HashExp{"message_id": message.Id}).Execute(); err != nil {
+				return err
+			}
+			if _, err := txDao.DB().Delete("embedding_failures", dbx.HashExp{"message_id": message.Id}).Execute(); err != nil {
+				return err
+			}
+		}
+
+		for i, passage := range passages {
+			record := models.NewRecord(collection)
+			record.Set("message_id", passage.message.Id)
+			record.Set("thread_id", passage.message.ThreadId)
+			record.Set("passage", passage.index)
+			record.Set("text", passage.text)
+			record.Set("model", key)
+			record.Set("message_updated", passage.message.Updated)
+			record.Set("vector", encodeVector(vectors[i]))
+			if err := txDao.SaveRecord(record); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// how much of the thread history can be searched by meaning
+func embeddingIndexStatus(app *pocketbase.PocketBase) string {
+	_, _, key, err := embeddingSettings(app)
+	if err != nil {
+		return ""
+	}
+
+	var counts struct {
+		Total   int `db:"total"`
+		Indexed int `db:"indexed"`
+		Failed  int `db:"failed"`
+	}
+	err = app.Dao().DB().
+		NewQuery(`SELECT COUNT(*) AS total,
+			COUNT(CASE WHEN EXISTS (SELECT 1 FROM message_embeddings e WHERE e.message_id = chat.id AND e.model = {:model}) THEN 1 END) AS indexed,
+			COUNT(CASE WHEN EXISTS (SELECT 1 FROM embedding_failures f WHERE f.message_id = chat.id AND f.model = {:model} AND f.message_updated = chat.updated) THEN 1 END) AS failed
+			FROM chat WHERE draft = FALSE AND sender IN ('human', 'model') AND TRIM(message) != ''`).
+		Bind(dbx.Params{"model": key}).
+		One(&counts)
+	if err != nil {
+		return ""
+	}
+	status := fmt.Sprintf("%d of %d messages indexed", counts.Indexed, counts.Total)
+	if counts.Failed > 0 {
+		status += fmt.Sprintf(", %d turned down by the API", counts.Failed)
+	}
+	return status
+}
+
+// a new model embeds the whole history again, the passages of the old one are dropped on the next pass
+func SaveEmbeddingSettings(data map[string]any, c echo.Context, app *pocketbase.PocketBase) error {
+	settingsRecord, err := app.Dao().FindFirstRecordByData("settings", "type", "keys")
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to find settings record")
+	}
+
+	settingsRecord.Set("embedding_api", FormValue(data, "embedding-api"))
+	settingsRecord.Set("embedding_model", strings.TrimSpace(FormValue(data, "embedding-model")))
+	if err := app.Dao().SaveRecord(settingsRecord); err != nil {
+		return c.String(http.StatusInternalServerError, "failed to update embedding settings")
+	}
+	// saving again also retries the messages the API turned down
+	if _, err := app.Dao().DB().NewQuery("DELETE FROM embedding_failures").Execute(); err != nil {
+		return c.String(http.StatusInternalServerError, "failed to clear embedding failures")
+	}
+	messageEmbeddings.nudge()
+
+	c.Response().Writer.WriteHeader(200)
+	settingsUpdated := templates.SettingsUpdated()
+	err = settingsUpdated.Render(context.Background(), c.Response().Writer)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to render settings update response")
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSplitPassages(t *testing.T) {
+	tests := map[string]string{
+		"short":      "a short message",
+		"paragraphs": strings.Repeat("some words in a paragraph. ", 30) + "\n\n" + strings.Repeat("another paragraph here. ", 30),
+		"long words": strings.Repeat("word ", 700),
+		"no spaces":  strings.Repeat("这是一个没有空格的很长的段落", 200),
+		"mixed":      "x" + strings.Repeat("日本語のテキスト", 300),
+	}
+	for name, text := range tests {
+		passages := splitPassages(text)
+		if len(passages) == 0 {
+			t.Errorf("%s: no passages", name)
+			continue
+		}
+		for i, passage := range passages {
+			if !utf8.ValidString(passage) {
+				t.Errorf("%s: passage %d isn't valid UTF-8", name, i)
+			}
+			if len(passage) > maxPassageLength+passageLength {
+				t.Errorf("%s: passage %d is %d bytes long", name, i, len(passage))
+			}
+		}
+		// nothing but whitespace is lost between passages
+		joined := strings.Join(strings.Fields(strings.Join(passages, "")), "")
+		if want := strings.Join(strings.Fields(text), ""); joined != want {
+			t.Errorf("%s: passages don't add up to the message", name)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/erikmillergalow/htmx-llmchat/templates"
+
+	"github.com/labstack/echo/v5"
+
+	"github.com/pocketbase/pocketbase"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// fallbacks are stored like compare models, an API id and a model name
+func loadApiFallbacks(apiRecord *models.Record) []compareModel {
+	var fallbacks []compareModel
+	raw := apiRecord.GetString("fallbacks")
+	if raw == "" || raw == "null" {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(raw), &fallbacks); err != nil {
+		fmt.Printf("Failed to read API fallbacks: %v\n", err)
+	}
+	return fallbacks
+}
+
+// an API and model a response can be requested from
+type chatTarget struct {
+	ApiRecord *models.Record
+	ModelName string
+	// name the response is shown and counted under
+	Name string
+}
+
+// the selected model, followed by the fallbacks of its API unless the generation has none
+func generationTargets(generation chatGeneration, app *pocketbase.PocketBase) []chatTarget {
+	targets := []chatTarget{{
+		ApiRecord: generation.ApiRecord,
+		ModelName: generation.ModelName,
+		Name:      generation.ChatModelName,
+	}}
+	if generation.NoFallbacks {
+		return targets
+	}
+
+	for _, fallback := range loadApiFallbacks(generation.ApiRecord) {
+		apiRecord, err := app.Dao().FindRecordById("apis", fallback.ApiId)
+		if err != nil {
+			fmt.Printf("Skipping fallback of a deleted API: %v\n", err)
+			continue
+		}
+		targets = append(targets, chatTarget{
+			ApiRecord: apiRecord,
+			ModelName: fallback.Model,
+			Name:      displayModelName(apiRecord, fallback.Model),
+		})
+	}
+	return targets
+}
+
+func renderApiFallbacks(apiRecord *models.Record, c echo.Context, app *pocketbase.PocketBase) error {
+	var params []templates.ApiFallbackParams
+	for i, fallback := range loadApiFallbacks(apiRecord) {
+		name := fallback.Model
+		if fallbackApiRecord, err := app.Dao().FindRecordById("apis", fallback.ApiId); err == nil {
+			name = displayModelName(fallbackApiRecord, fallback.Model)
+		}
+		params = append(params, templates.ApiFallbackParams{Index: i, Name: name})
+	}
+
+	var apiParams []templates.ApiParams
+	app.Dao().DB().
+		Select("*").
+		From("apis").
+		OrderBy("name ASC").
+		All(&apiParams)
+
+	c.Response().Writer.WriteHeader(200)
+	fallbacks := templates.ApiFallbacks(apiRecord.Id, params, apiParams)
+	err := fallbacks.Render(context.Background(), c.Response().Writer)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to render API fallbacks")
+	}
+
+	return nil
+}
+
+func GetApiFallbacks(id string, c echo.Context, app *pocketbase.PocketBase) error {
+	apiRecord, err := app.Dao().FindRecordById("apis", id)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to find api record for fallbacks")
+	}
+
+	return renderApiFallbacks(apiRecord, c, app)
+}
+
+// add an API and model to the end of the fallback chain
+func AddApiFallback(id string, data map[string]any, c echo.Context, app *pocketbase.PocketBase) error {
+	apiRecord, err := app.Dao().FindRecordById("apis", id)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to find api record for fallbacks")
+	}
+
+	fallback := compareModel{
+		ApiId: FormValue(data, "fallback-api"),
+		Model: strings.TrimSpace(FormValue(data, "fallback-model")),
+	}
+	if _, err := app.Dao().FindRecordById("apis", fallback.ApiId); err != nil || fallback.ApiId == id {
+		return renderApiFallbacks(apiRecord, c, app)
+	}
+
+	fallbacks := loadApiFallbacks(apiRecord)
+	if !slices.Contains(fallbacks, fallback) {
+		apiRecord.Set("fallbacks", append(fallbacks, fallback))
+		if err := app.Dao().SaveRecord(apiRecord); err != nil {
+			return c.String(http.StatusInternalServerError, "failed to update API fallbacks")
+		}
+	}
+
+	return renderApiFallbacks(apiRecord, c, app)
+}
+
+func RemoveApiFallback(id string, index string, c echo.Context, app *pocketbase.PocketBase) error {
+	apiRecord, err := app.Dao().FindRecordById("apis", id)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to find api record for fallbacks")
+	}
+
+	fallbacks := loadApiFallbacks(apiRecord)
+	i, err := strconv.Atoi(index)
+	if err != nil || i < 0 || i >= len(fallbacks) {
+		return c.String(http.StatusBadRequest, "unknown fallback")
+	}
+
+	apiRecord.Set("fallbacks", slices.Delete(fallbacks, i, i+1))
+	if err := app.Dao().SaveRecord(apiRecord); err != nil {
+		return c.String(http.StatusInternalServerError, "failed to update API fallbacks")
+	}
+
+	return renderApiFallbacks(apiRecord, c, app)
+}
@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/erikmillergalow/htmx-llmchat/templates"
+
+	"github.com/labstack/echo/v5"
+	openai "github.com/sashabaranov/go-openai"
+
+	"github.com/pocketbase/pocketbase"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+func parseFloatParam(data map[string]any, key string) (*float32, error) {
+	value := strings.TrimSpace(FormValue(data, key))
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := strconv.ParseFloat(value, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s", key)
+	}
+	result := float32(parsed)
+	return &result, nil
+}
+
+func parseIntParam(data map[string]any, key string) (*int, error) {
+	value := strings.TrimSpace(FormValue(data, key))
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s", key)
+	}
+	return &parsed, nil
+}
+
+// read the parameter inputs rendered by GenerationParamsInputs, empty inputs stay unset
+func parseGenerationParams(data map[string]any) (templates.GenerationParams, error) {
+	var params templates.GenerationParams
+	var err error
+
+	if params.Temperature, err = parseFloatParam(data, "temperature"); err != nil {
+		return params, err
+	}
+	if params.TopP, err = parseFloatParam(data, "top-p"); err != nil {
+		return params, err
+	}
+	if params.MaxTokens, err = parseIntParam(data, "max-tokens"); err != nil {
+		return params, err
+	}
+	if params.PresencePenalty, err = parseFloatParam(data, "presence-penalty"); err != nil {
+		return params, err
+	}
+	if params.FrequencyPenalty, err = parseFloatParam(data, "frequency-penalty"); err != nil {
+		return params, err
+	}
+	if params.Seed, err = parseIntParam(data, "seed"); err != nil {
+		return params, err
+	}
+
+	// one sequence per line, \n stands for a newline inside a sequence
+	for _, line := range strings.Split(FormValue(data, "stop"), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if line == "" {
+			continue
+		}
+		params.Stop = append(params.Stop, strings.ReplaceAll(line, `\n`, "\n"))
+	}
+
+	return params, nil
+}
+
+func loadGenerationParams(record *models.Record) templates.GenerationParams {
+	var params templates.GenerationParams
+	if err := params.Scan(record.GetString("generation_params")); err != nil {
+		fmt.Printf("Failed to read generation params of %s: %v\n", record.Id, err)
+	}
+	return params
+}
+
+// thread parameters with anything left unset taken from the API defaults
+func resolveGenerationParams(threadRecord *models.Record, apiRecord *models.Record) templates.GenerationParams {
+	params := loadGenerationParams(threadRecord)
+	if apiRecord == nil {
+		return params
+	}
+	defaults := loadGenerationParams(apiRecord)
+
+	if params.Temperature == nil {
+		params.Temperature = defaults.Temperature
+	}
+	if params.TopP == nil {
+		params.TopP = defaults.TopP
+	}
+	if params.MaxTokens == nil {
+		params.MaxTokens = defaults.MaxTokens
+	}
+	if params.PresencePenalty == nil {
+		params.PresencePenalty = defaults.PresencePenalty
+	}
+	if params.FrequencyPenalty == nil {
+		params.FrequencyPenalty = defaults.FrequencyPenalty
+	}
+	if len(params.Stop) == 0 {
+		params.Stop = defaults.Stop
+	}
+	if params.Seed == nil {
+		params.Seed = defaults.Seed
+	}
+
+	return params
+}
+
+// parameters for a new message in a thread, only the API defaults if the thread can't be read
+func threadGenerationParams(threadId string, apiRecord *models.Record, app *pocketbase.PocketBase) templates.GenerationParams {
+	threadRecord, err := app.Dao().FindRecordById("chat_meta", threadId)
+	if err != nil {
+		return loadGenerationParams(apiRecord)
+	}
+	return resolveGenerationParams(threadRecord, apiRecord)
+}
+
+// go-openai drops zero floats from requests, the smallest float keeps an explicit 0
+func explicitFloat(value float32) float32 {
+	if value == 0 {
+		return math.SmallestNonzeroFloat32
+	}
+	return value
+}
+
+// a float from a go-openai request for APIs with their own request types, nil when unset
+func requestFloat(value float32) *float64 {
+	if value == 0 {
+		return nil
+	}
+	// an explicit 0 is marked with the smallest float
+	if value == math.SmallestNonzeroFloat32 {
+		value = 0
+	}
+	// float32 values like 0.7 would otherwise be sent as 0.699999988
+	rounded := math.Round(float64(value)*1e6) / 1e6
+	return &rounded
+}
+
+func applyGenerationParams(req *openai.ChatCompletionRequest, params templates.GenerationParams) {
+	if params.Temperature != nil {
+		req.Temperature = explicitFloat(*params.Temperature)
+	}
+	if params.TopP != nil {
+		req.TopP = explicitFloat(*params.TopP)
+	}
+	if params.MaxTokens != nil {
+		req.MaxTokens = *params.MaxTokens
+	}
+	// penalties default to 0, leaving them out is the same
+	if params.PresencePenalty != nil {
+		req.PresencePenalty = *params.PresencePenalty
+	}
+	if params.FrequencyPenalty != nil {
+		req.FrequencyPenalty = *params.FrequencyPenalty
+	}
+	if len(params.Stop) > 0 {
+		req.Stop = params.Stop
+	}
+	if params.Seed != nil {
+		seed := *params.Seed
+		req.Seed = &seed
+	}
+}
+
+func SaveThreadGenerationParams(id string, data map[string]any, c echo.Context, app *pocketbase.PocketBase) error {
+	threadRecord, err := app.Dao().FindRecordById("chat_meta", id)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to find thread record to set parameters")
+	}
+
+	status := "Parameters updated"
+	params, err := parseGenerationParams(data)
+	if err != nil {
+		status = "Parameters not saved, " + err.Error()
+	} else {
+		threadRecord.Set("generation_params", params)
+		if err := app.Dao().SaveRecord(threadRecord); err != nil {
+			return c.String(http.StatusInternalServerError, "failed to update thread parameters")
+		}
+	}
+
+	c.Response().Writer.WriteHeader(200)
+	paramsStatus := templates.GenerationParamsStatus(status)
+	err = paramsStatus.Render(context.Background(), c.Response().Writer)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to render parameters status")
+	}
+
+	return nil
+}
@@ -0,0 +1,425 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/erikmillergalow/htmx-llmchat/markdown"
+	"github.com/erikmillergalow/htmx-llmchat/templates"
+
+	"github.com/a-h/templ"
+	"github.com/pocketbase/pocketbase"
+)
+
+// anything the rendered updates of a generation can be written to
+type componentWriter interface {
+	writeComponent(component templ.Component) error
+}
+
+// the unfinished end of a response is rendered again at most this often, finished blocks go out right away
+const tailInterval = 100 * time.Millisecond
+
+// what a frame does, so frames that later ones make redundant can be dropped from the resume buffer
+type frameKind int
+
+const (
+	frameOther frameKind = iota
+	// replaces the unfinished end of the response
+	frameTail
+	// appends finished blocks and replaces the unfinished end
+	frameBlocks
+	// appends thinking
+	frameReasoning
+)
+
+type streamFrame struct {
+	seq  int
+	kind frameKind
+	html []byte
+	// thinking frames in a row are kept as one, rendered again when resuming
+	// with the offset in reasoning where each of their chunks starts
+	firstSeq  int
+	reasoning string
+	offsets   []int
+}
+
+// a response being streamed, kept apart from the socket that started it so a
+// reconnecting client can pick it up where it left off
+type generationJob struct {
+	ThreadId  string
+	MessageId string
+
+	mu sync.Mutex
+	// rendered updates for resuming, without the ones later frames replace
+	frames []streamFrame
+	// sequence number of the last frame
+	seq int
+	// response and thinking so far, shown when the thread is reloaded mid-stream
+	text        string
+	reasoning   string
+	subscribers map[*chatSocket]bool
+	// when the unfinished end was last sent, only the generation reads and writes it
+	tailSent time.Time
+}
+
+// render an update, number it and send it to every subscribed socket
+// chunks are added to the response text and thinking along with the frame, so snapshots and frames agree
+func (j *generationJob) publish(kind frameKind, component templ.Component, chunk string, reasoning string, numbered bool) error {
+	var htmlBuf bytes.Buffer
+	if err := component.Render(context.Background(), &htmlBuf); err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	// the marker lets the client skip frames it already has after resuming
+	j.seq++
+	if numbered {
+		if err := templates.StreamMarkerSwap(j.MessageId, j.seq).Render(context.Background(), &htmlBuf); err != nil {
+			return err
+		}
+	}
+	j.text += chunk
+	j.reasoning += reasoning
+	j.keepFrame(kind, htmlBuf.Bytes(), reasoning)
+
+	for socket := range j.subscribers {
+		if err := socket.writeMessage(htmlBuf.Bytes()); err != nil {
+			fmt.Printf("dropping generation subscriber after write failure: %v\n", err)
+			delete(j.subscribers, socket)
+		}
+	}
+
+	return nil
+}
+
+// add the frame with the current sequence number to the resume buffer
+func (j *generationJob) keepFrame(kind frameKind, html []byte, reasoning string) {
+	var last *streamFrame
+	if len(j.frames) > 0 {
+		last = &j.frames[len(j.frames)-1]
+	}
+
+	switch {
+	case (kind == frameTail || kind == frameBlocks) && last != nil && last.kind == frameTail:
+		// the new frame sets the unfinished end again, so the last one is no longer needed
+		*last = streamFrame{seq: j.seq, kind: kind, html: html}
+	case kind == frameReasoning && last != nil && last.kind == frameReasoning:
+		last.seq = j.seq
+		last.offsets = append(last.offsets, len(last.reasoning))
+		last.reasoning += reasoning
+	case kind == frameReasoning:
+		j.frames = append(j.frames, streamFrame{seq: j.seq, kind: kind, firstSeq: j.seq, reasoning: reasoning, offsets: []int{0}})
+	default:
+		j.frames = append(j.frames, streamFrame{seq: j.seq, kind: kind, html: html})
+	}
+}
+
+func (j *generationJob) writeComponent(component templ.Component) error {
+	return j.publish(frameOther, component, "", "", true)
+}
+
+// finished blocks are sent right away, the rest waits until tailInterval has passed since it was last sent
+// only the generation writes to its job, so the text can't change between reading and publishing
+func (j *generationJob) writeChunk(chunk string) error {
+	j.mu.Lock()
+	text := j.text
+	j.mu.Unlock()
+
+	blocks, rest, finished := markdown.StreamUpdate(text, chunk)
+	if !finished && time.Since(j.tailSent) < tailInterval {
+		// shown with the next frame, and with the whole response once the stream ends
+		j.mu.Lock()
+		j.text += chunk
+		j.mu.Unlock()
+		return nil
+	}
+
+	kind := frameTail
+	if finished {
+		kind = frameBlocks
+	}
+	j.tailSent = time.Now()
+	return j.publish(kind, templates.ChatStreamChunk(j.MessageId, blocks, markdown.RenderTail(rest)), chunk, "", true)
+}
+
+func (j *generationJob) writeReasoning(chunk string) error {
+	return j.publish(frameReasoning, templates.ReasoningStreamChunk(j.MessageId, chunk), "", chunk, true)
+}
+
+// clear the response so far, for an attempt that is started over
+func (j *generationJob) restart() error {
+	j.mu.Lock()
+	j.text = ""
+	j.reasoning = ""
+	j.mu.Unlock()
+	j.tailSent = time.Time{}
+
+	return j.publish(frameOther, templates.ChatStreamReset(j.MessageId), "", "", true)
+}
+
+// the response and thinking so far and the sequence number of the last frame they include
+func (j *generationJob) snapshot() (string, string, int) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.text, j.reasoning, j.seq
+}
+
+// send the frames a socket missed and keep it updated from then on
+func (j *generationJob) resume(socket *chatSocket, after int) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	for _, frame := range j.frames {
+		if frame.seq <= after {
+			continue
+		}
+		html := frame.html
+		if frame.kind == frameReasoning {
+			// only the thinking that came after the frames the socket has
+			reasoning := frame.reasoning
+			if after >= frame.firstSeq {
+				reasoning = reasoning[frame.offsets[after-frame.firstSeq+1]:]
+			}
+			var htmlBuf bytes.Buffer
+			templates.ReasoningStreamChunk(j.MessageId, reasoning).Render(context.Background(), &htmlBuf)
+			templates.StreamMarkerSwap(j.MessageId, frame.seq).Render(context.Background(), &htmlBuf)
+			html = htmlBuf.Bytes()
+		}
+		if err := socket.writeMessage(html); err != nil {
+			fmt.Printf("failed to resume generation: %v\n", err)
+			return
+		}
+	}
+	j.subscribers[socket] = true
+}
+
+// generations by thread and by the model message they fill in
+type generationManager struct {
+	mu sync.Mutex
+	// cancels the generation running in a thread
+	threads map[string]context.CancelFunc
+	jobs    map[string]*generationJob
+	// open sockets, told whenever a thread starts or stops generating or a model is pulled
+	sockets map[*chatSocket]bool
+}
+
+var generationJobs = &generationManager{
+	threads: make(map[string]context.CancelFunc),
+	jobs:    make(map[string]*generationJob),
+	sockets: make(map[*chatSocket]bool),
+}
+
+// claim a thread for a new generation, false if one is already running there
+func (m *generationManager) reserveThread(threadId string, cancel context.CancelFunc) bool {
+	m.mu.Lock()
+	if _, busy := m.threads[threadId]; busy {
+		m.mu.Unlock()
+		return false
+	}
+	m.threads[threadId] = cancel
+	m.mu.Unlock()
+
+	m.broadcastGenerating()
+	return true
+}
+
+func (m *generationManager) releaseThread(threadId string) {
+	m.mu.Lock()
+	delete(m.threads, threadId)
+	m.mu.Unlock()
+
+	m.broadcastGenerating()
+}
+
+func (m *generationManager) generating(threadId string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, busy := m.threads[threadId]
+	return busy
+}
+
+// threads with a generation running, in no particular order
+func (m *generationManager) generatingThreads() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	threads := make([]string, 0, len(m.threads))
+	for threadId := range m.threads {
+		threads = append(threads, threadId)
+	}
+	return threads
+}
+
+// model messages still being streamed into
+func (m *generationManager) messageIds() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	messageIds := make([]string, 0, len(m.jobs))
+	for messageId := range m.jobs {
+		messageIds = append(messageIds, messageId)
+	}
+	return messageIds
+}
+
+// start telling a socket which threads are generating, beginning with the current ones
+func (m *generationManager) connect(socket *chatSocket) {
+	m.mu.Lock()
+	m.sockets[socket] = true
+	m.mu.Unlock()
+
+	if err := socket.writeComponent(templates.GeneratingThreads(m.generatingThreads())); err != nil {
+		fmt.Printf("failed to write generating threads: %v\n", err)
+	}
+}
+
+// update the thread list indicators of every open socket
+func (m *generationManager) broadcastGenerating() {
+	m.broadcast(templates.GeneratingThreads(m.generatingThreads()))
+}
+
+// write a component to every open socket
+func (m *generationManager) broadcast(component templ.Component) {
+	m.mu.Lock()
+	sockets := make([]*chatSocket, 0, len(m.sockets))
+	for socket := range m.sockets {
+		sockets = append(sockets, socket)
+	}
+	m.mu.Unlock()
+
+	for _, socket := range sockets {
+		if err := socket.writeComponent(component); err != nil {
+			fmt.Printf("failed to write to socket: %v\n", err)
+		}
+	}
+}
+
+func (m *generationManager) writeComponent(component templ.Component) error {
+	m.broadcast(component)
+	return nil
+}
+
+func (m *generationManager) cancelThread(threadId string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if cancel, ok := m.threads[threadId]; ok {
+		cancel()
+	}
+}
+
+func (m *generationManager) start(threadId string, messageId string, socket *chatSocket) *generationJob {
+	job := &generationJob{
+		ThreadId:    threadId,
+		MessageId:   messageId,
+		subscribers: map[*chatSocket]bool{socket: true},
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.jobs[messageId] = job
+	return job
+}
+
+// a job for the next message of a generation, sent to the sockets following the current one
+func (m *generationManager) handOver(job *generationJob, messageId string) *generationJob {
+	job.mu.Lock()
+	subscribers := make(map[*chatSocket]bool, len(job.subscribers))
+	for socket := range job.subscribers {
+		subscribers[socket] = true
+	}
+	job.mu.Unlock()
+
+	next := &generationJob{
+		ThreadId:    job.ThreadId,
+		MessageId:   messageId,
+		subscribers: subscribers,
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.jobs[messageId] = next
+	return next
+}
+
+// the response is saved, later resumes load it from the DB
+func (m *generationManager) finish(job *generationJob) {
+	// the marker is removed here, so this frame isn't numbered
+	if err := job.publish(frameOther, templates.StreamFinished(job.MessageId), "", "", false); err != nil {
+		fmt.Printf("failed to write stream end: %v\n", err)
+	}
+
+	m.mu.Lock()
+	delete(m.jobs, job.MessageId)
+	m.mu.Unlock()
+
+	// the saved response, and the message it answers, can be embedded now
+	messageEmbeddings.nudge()
+}
+
+func (m *generationManager) job(messageId string) *generationJob {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.jobs[messageId]
+}
+
+// stop sending updates to a closed socket, its generations keep running
+func (m *generationManager) unsubscribe(socket *chatSocket) {
+	m.mu.Lock()
+	delete(m.sockets, socket)
+	jobs := make([]*generationJob, 0, len(m.jobs))
+	for _, job := range m.jobs {
+		jobs = append(jobs, job)
+	}
+	m.mu.Unlock()
+
+	for _, job := range jobs {
+		job.mu.Lock()
+		delete(job.subscribers, socket)
+		job.mu.Unlock()
+	}
+}
+
+// continue a stream on a reconnected socket, or show the saved response if it already finished
+func resumeGeneration(messageId string, after int, socket *chatSocket, app *pocketbase.PocketBase) {
+	if job := generationJobs.job(messageId); job != nil {
+		job.resume(socket, after)
+		return
+	}
+
+	messageRecord, err := app.Dao().FindRecordById("chat", messageId)
+	if err != nil {
+		fmt.Printf("failed to find resumed message: %v\n", err)
+		return
+	}
+
+	tree, err := loadMessageTree(messageRecord.GetString("thread_id"), app)
+	if err != nil {
+		fmt.Printf("failed to load resumed message: %v\n", err)
+		return
+	}
+
+	// compare answers are shown as columns without branch controls
+	message := tree.withSiblings(messageId)
+	if parent, ok := tree.messages[message.ParentId]; ok && parent.Compare != "" {
+		message.CompareState = parent.Compare
+		message.Siblings = templates.SiblingNavParams{}
+	}
+
+	if err := socket.writeComponent(templates.ModelMessageResumed(message)); err != nil {
+		fmt.Printf("failed to write resumed message: %v\n", err)
+	}
+}
+
+// show what has been generated so far if the message is still streaming
+func withStreamSnapshot(message *templates.LoadedMessageParams) {
+	if job := generationJobs.job(message.Id); job != nil {
+		message.Message, message.Reasoning, message.StreamSeq = job.snapshot()
+		message.Streaming = true
+	}
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// a socket the job writes to and the client end that reads what it was sent
+func newTestSocket(t *testing.T) (*chatSocket, *websocket.Conn) {
+	conns := make(chan *websocket.Conn, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upgrader := websocket.Upgrader{}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		conns <- conn
+	}))
+	t.Cleanup(server.Close)
+
+	client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	conn := <-conns
+	t.Cleanup(func() { conn.Close() })
+	return &chatSocket{ws: conn}, client
+}
+
+func readFrames(t *testing.T, client *websocket.Conn, count int) []string {
+	var frames []string
+	for range count {
+		client.SetReadDeadline(time.Now().Add(5 * time.Second))
+		_, data, err := client.ReadMessage()
+		if err != nil {
+			t.Fatal(err)
+		}
+		frames = append(frames, string(data))
+	}
+	return frames
+}
+
+// a long code answer streamed a few characters at a time keeps only what resuming needs
+func TestGenerationJobKeepsFewFrames(t *testing.T) {
+	job := &generationJob{MessageId: "m1", subscribers: make(map[*chatSocket]bool)}
+
+	var answer strings.Builder
+	answer.WriteString("Here is the program:\n\n```go\npackage main\n\n")
+	for i := range 300 {
+		answer.WriteString("\tfmt.Println(\"line \", " + strings.Repeat("x", i%7) + ")\n")
+	}
+	answer.WriteString("```\n\nThat's all.")
+	text := answer.String()
+
+	for i := 0; i < len(text); i += 4 {
+		if err := job.writeChunk(text[i:min(i+4, len(text))]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	streamed, _, seq := job.snapshot()
+	if streamed != text {
+		t.Errorf("snapshot text differs from the streamed answer")
+	}
+	size := 0
+	for _, frame := range job.frames {
+		size += len(frame.html)
+	}
+	t.Logf("%d chunks, %d frames published, %d kept, %d bytes", (len(text)+3)/4, seq, len(job.frames), size)
+	// one frame for each of the two finished blocks and the last unfinished end
+	if len(job.frames) > 3 {
+		t.Errorf("kept %d frames, want at most 3", len(job.frames))
+	}
+	if last := job.frames[len(job.frames)-1]; last.seq != seq {
+		t.Errorf("last kept frame has sequence number %d, want %d", last.seq, seq)
+	}
+}
+
+func TestGenerationJobResume(t *testing.T) {
+	job := &generationJob{MessageId: "m1", subscribers: make(map[*chatSocket]bool)}
+	for _, chunk := range []string{"first ", "second ", "third"} {
+		if err := job.writeReasoning(chunk); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := job.writeChunk("The answer"); err != nil {
+		t.Fatal(err)
+	}
+	if len(job.frames) != 2 {
+		t.Fatalf("kept %d frames, want the thinking and the answer", len(job.frames))
+	}
+
+	socket, client := newTestSocket(t)
+
+	// a socket that has the first chunk of thinking only gets the rest
+	job.resume(socket, 1)
+	frames := readFrames(t, client, 2)
+	if !strings.Contains(frames[0], "second third") || strings.Contains(frames[0], "first") {
+		t.Errorf("resumed thinking = %s, want the chunks after the first", frames[0])
+	}
+	if !strings.Contains(frames[0], `data-seq="3"`) {
+		t.Errorf("resumed thinking should carry the marker of its last chunk: %s", frames[0])
+	}
+	if !strings.Contains(frames[1], "The answer") || !strings.Contains(frames[1], `data-seq="4"`) {
+		t.Errorf("resumed answer = %s", frames[1])
+	}
+
+	// new frames follow the resumed ones
+	if err := job.writeReasoning("more"); err != nil {
+		t.Fatal(err)
+	}
+	if frame := readFrames(t, client, 1)[0]; !strings.Contains(frame, "more") || !strings.Contains(frame, `data-seq="5"`) {
+		t.Errorf("frame after resuming = %s", frame)
+	}
+}
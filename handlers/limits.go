@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// requests in flight per API, held to the max_concurrent of the API
+type apiLimiter struct {
+	mu     sync.Mutex
+	active map[string]int
+	// closed and replaced whenever a request finishes, wakes up the waiting ones
+	released chan struct{}
+}
+
+var apiLimits = &apiLimiter{
+	active:   make(map[string]int),
+	released: make(chan struct{}),
+}
+
+// true if the API has a free slot right now
+func (l *apiLimiter) tryAcquire(apiRecord *models.Record) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	limit := apiRecord.GetInt("max_concurrent")
+	if limit > 0 && l.active[apiRecord.Id] >= limit {
+		return false
+	}
+	l.active[apiRecord.Id]++
+	return true
+}
+
+// wait for a free slot on the API, call the returned release once the request is done
+// waiting is called first when the API is full, so the wait can be shown
+func (l *apiLimiter) acquire(ctx context.Context, apiRecord *models.Record, waiting func()) (func(), error) {
+	for notified := false; ; notified = true {
+		l.mu.Lock()
+		released := l.released
+		l.mu.Unlock()
+
+		if l.tryAcquire(apiRecord) {
+			return func() { l.release(apiRecord.Id) }, nil
+		}
+		if !notified && waiting != nil {
+			waiting()
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-released:
+		}
+	}
+}
+
+func (l *apiLimiter) release(apiId string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.active[apiId]--
+	if l.active[apiId] <= 0 {
+		delete(l.active, apiId)
+	}
+	close(l.released)
+	l.released = make(chan struct{})
+}
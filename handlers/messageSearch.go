@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// keep the full-text index in step with saved messages and thread titles
+// messages removed with plain SQL stay in the index, results leave them out by joining on chat
+func RegisterSearchIndexHooks(app *pocketbase.PocketBase) {
+	indexRecord := func(e *core.ModelEvent) error {
+		if record, ok := e.Model.(*models.Record); ok {
+			if err := updateSearchIndex(e.Dao, record); err != nil {
+				fmt.Printf("Failed to update search index: %v\n", err)
+			}
+		}
+		return nil
+	}
+	app.OnModelAfterCreate("chat", "chat_meta").Add(indexRecord)
+	app.OnModelAfterUpdate("chat", "chat_meta").Add(indexRecord)
+
+	// the rowid is gone after the delete, a delete that then fails is indexed again on the next save
+	app.OnModelBeforeDelete("chat", "chat_meta").Add(func(e *core.ModelEvent) error {
+		if record, ok := e.Model.(*models.Record); ok {
+			if err := removeFromSearchIndex(e.Dao, record); err != nil {
+				fmt.Printf("Failed to remove from search index: %v\n", err)
+			}
+		}
+		return nil
+	})
+}
+
+// rows share the rowid of the message they index, titles the negated rowid of their thread
+func searchRowid(dao *daos.Dao, record *models.Record) (int64, error) {
+	var rowid int64
+	err := dao.DB().
+		Select("rowid").
+		From(record.TableName()).
+		Where(dbx.HashExp{"id": record.Id}).
+		Row(&rowid)
+	if record.TableName() == "chat_meta" {
+		rowid = -rowid
+	}
+	return rowid, err
+}
+
+func updateSearchIndex(dao *daos.Dao, record *models.Record) error {
+	rowid, err := searchRowid(dao, record)
+	if err != nil {
+		return err
+	}
+
+	body, messageId, threadId := "", record.Id, record.GetString("thread_id")
+	if record.TableName() == "chat_meta" {
+		messageId, threadId = "", record.Id
+		// new threads are titled with their id until they are named
+		if title := record.GetString("thread_title"); title != record.Id {
+			body = title
+		}
+	} else if !record.GetBool("draft") && (record.GetString("sender") == "human" || record.GetString("sender") == "model") {
+		body = record.GetString("message")
+	}
+
+	// a rowid freed by a message deleted with plain SQL is taken over here
+	return dao.RunInTransaction(func(txDao *daos.Dao) error {
+		if _, err := txDao.DB().Delete("message_search", dbx.HashExp{"rowid": rowid}).Execute(); err != nil {
+			return err
+		}
+		if strings.TrimSpace(body) == "" {
+			return nil
+		}
+		_, err := txDao.DB().Insert("message_search", dbx.Params{
+			"rowid":      rowid,
+			"body":       body,
+			"message_id": messageId,
+			"thread_id":  threadId,
+		}).Execute()
+		return err
+	})
+}
+
+// a deleted thread takes the index rows of its messages along
+func removeFromSearchIndex(dao *daos.Dao, record *models.Record) error {
+	rowid, err := searchRowid(dao, record)
+	if err != nil {
+		return err
+	}
+	if _, err := dao.DB().Delete("message_search", dbx.HashExp{"rowid": rowid}).Execute(); err != nil {
+		return err
+	}
+	if record.TableName() != "chat_meta" {
+		return nil
+	}
+	_, err = dao.DB().
+		NewQuery("DELETE FROM message_search WHERE rowid IN (SELECT rowid FROM chat WHERE thread_id = {:thread})").
+		Bind(dbx.Params{"thread": record.Id}).
+		Execute()
+	return err
+}
+
+// control characters marking matches in snippets, dropped from the text around them
+const (
+	snippetOpen  = "\x02"
+	snippetClose = "\x03"
+)
+
+// a snippet as HTML, escaped with only the matches marked
+func highlightSnippet(snippet string) string {
+	var highlighted strings.Builder
+	marked := false
+	for _, char := range snippet {
+		switch string(char) {
+		case snippetOpen:
+			if !marked {
+				highlighted.WriteString("<mark>")
+				marked = true
+			}
+		case snippetClose:
+			if marked {
+				highlighted.WriteString("</mark>")
+				marked = false
+			}
+		default:
+			highlighted.WriteString(html.EscapeString(string(char)))
+		}
+	}
+	if marked {
+		highlighted.WriteString("</mark>")
+	}
+	return highlighted.String()
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"github.com/erikmillergalow/htmx-llmchat/templates"
+)
+
+// timings of the request that answered, saved with the model message
+// left at 0 when the response failed or was stopped before it was requested
+func responseMetrics(result streamResult, completionTokens int) templates.LoadedMessageParams {
+	var metrics templates.LoadedMessageParams
+	if result.Started.IsZero() || result.Ended.IsZero() {
+		return metrics
+	}
+
+	metrics.Timestamp = result.Started.UnixMilli()
+	metrics.DurationMs = max(int(result.Ended.Sub(result.Started).Milliseconds()), 1)
+	metrics.FinishReason = result.FinishReason
+	if result.FirstToken.IsZero() {
+		return metrics
+	}
+
+	metrics.TTFTMs = int(result.FirstToken.Sub(result.Started).Milliseconds())
+	// counted from the first token so the wait for it doesn't drag the rate down
+	if generating := result.Ended.Sub(result.FirstToken); generating > 0 && completionTokens > 0 {
+		metrics.TokensPerSecond = math.Round(float64(completionTokens)/generating.Seconds()*10) / 10
+	}
+	return metrics
+}
+
+// nearest-rank percentile of sorted values
+func percentile(sorted []int, p float64) int {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	return sorted[min(max(rank, 0), len(sorted)-1)]
+}
+
+// median and p95 of the timed responses of a model
+func latencyStats(ttfts []int, durations []int, rates []float64) templates.LatencyParams {
+	slices.Sort(ttfts)
+	slices.Sort(durations)
+	slices.Sort(rates)
+
+	stats := templates.LatencyParams{
+		Samples:        len(durations),
+		TTFTMedian:     time.Duration(percentile(ttfts, 0.5)) * time.Millisecond,
+		TTFTP95:        time.Duration(percentile(ttfts, 0.95)) * time.Millisecond,
+		DurationMedian: time.Duration(percentile(durations, 0.5)) * time.Millisecond,
+		DurationP95:    time.Duration(percentile(durations, 0.95)) * time.Millisecond,
+	}
+	if len(rates) > 0 {
+		stats.TokensPerSecond = rates[(len(rates)-1)/2]
+	}
+	return stats
+}
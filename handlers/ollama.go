@@ -0,0 +1,518 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	openai "github.com/sashabaranov/go-openai"
+
+	"github.com/pocketbase/pocketbase/models"
+)
+
+const ollamaDefaultUrl = "http://localhost:11434"
+
+// a local Ollama server through its own API, which can also pull and delete models
+type ollamaProvider struct {
+	apiRecord *models.Record
+}
+
+func newOllamaProvider(apiRecord *models.Record) Provider {
+	return &ollamaProvider{apiRecord: apiRecord}
+}
+
+// the Ollama of an API record, an error if the API is of another type
+func ollamaProviderFor(apiRecord *models.Record) (*ollamaProvider, error) {
+	provider, err := newProvider(apiRecord)
+	if err != nil {
+		return nil, err
+	}
+	ollama, ok := provider.(*ollamaProvider)
+	if !ok {
+		return nil, fmt.Errorf("API %s is not an Ollama server", apiRecord.GetString("name"))
+	}
+	return ollama, nil
+}
+
+// an URL copied from the OpenAI compatible setup still works
+func (p *ollamaProvider) url(path string) string {
+	base := strings.TrimSuffix(strings.TrimSuffix(p.apiRecord.GetString("url"), "/"), "/v1")
+	if base == "" {
+		base = ollamaDefaultUrl
+	}
+	return base + path
+}
+
+func (p *ollamaProvider) do(ctx context.Context, method string, path string, body any) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, p.url(path), reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	// for servers behind an authenticating proxy
+	if apiKey := p.apiRecord.GetString("api_key"); apiKey != "" {
+		request.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+
+	client := &http.Client{Transport: &retryAfterTransport{base: http.DefaultTransport}}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		defer response.Body.Close()
+		return nil, readOllamaError(response.StatusCode, response.Body)
+	}
+	return response, nil
+}
+
+// errors come back as go-openai API errors so retries treat every backend alike
+func readOllamaError(status int, body io.Reader) error {
+	data, _ := io.ReadAll(body)
+
+	var ollamaErr struct {
+		Error string `json:"error"`
+	}
+	message := strings.TrimSpace(string(data))
+	if json.Unmarshal(data, &ollamaErr) == nil && ollamaErr.Error != "" {
+		message = ollamaErr.Error
+	}
+	return &openai.APIError{Message: message, HTTPStatusCode: status}
+}
+
+type ollamaModel struct {
+	Name    string `json:"name"`
+	Size    int64  `json:"size"`
+	Digest  string `json:"digest"`
+	Details struct {
+		ParameterSize     string `json:"parameter_size"`
+		QuantizationLevel string `json:"quantization_level"`
+	} `json:"details"`
+}
+
+// models pulled to the server
+func (p *ollamaProvider) installedModels(ctx context.Context) ([]ollamaModel, error) {
+	response, err := p.do(ctx, http.MethodGet, "/api/tags", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	var data struct {
+		Models []ollamaModel `json:"models"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to read Ollama models: %w", err)
+	}
+	return data.Models, nil
+}
+
+// context lengths read with /api/show, keyed by model digest since they never change for one
+var ollamaContextLengths sync.Map
+
+// context length the model was trained with, 0 if the server doesn't say
+func (p *ollamaProvider) contextLength(ctx context.Context, model ollamaModel) int {
+	if length, ok := ollamaContextLengths.Load(model.Digest); ok {
+		return length.(int)
+	}
+
+	response, err := p.do(ctx, http.MethodPost, "/api/show", map[string]any{"model": model.Name})
+	if err != nil {
+		fmt.Printf("Failed to show Ollama model %s: %v\n", model.Name, err)
+		return 0
+	}
+	defer response.Body.Close()
+
+	var data struct {
+		ModelInfo map[string]any `json:"model_info"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&data); err != nil {
+		fmt.Printf("Failed to read Ollama model %s: %v\n", model.Name, err)
+		return 0
+	}
+
+	// the key is prefixed with the architecture, llama.context_length and so on
+	length := 0
+	for key, value := range data.ModelInfo {
+		if number, ok := value.(float64); ok && strings.HasSuffix(key, ".context_length") {
+			length = int(number)
+		}
+	}
+	ollamaContextLengths.Store(model.Digest, length)
+	return length
+}
+
+func formatBytes(size int64) string {
+	const unit = 1000
+	if size < unit {
+		return strconv.FormatInt(size, 10) + " B"
+	}
+	value := float64(size)
+	prefix := -1
+	for value >= unit && prefix < 3 {
+		value /= unit
+		prefix++
+	}
+	return strconv.FormatFloat(value, 'f', 1, 64) + " " + string("kMGT"[prefix]) + "B"
+}
+
+// parameter count, quantization and context window of a model
+func ollamaModelDetails(model ollamaModel, contextWindow int) string {
+	var details []string
+	if model.Details.ParameterSize != "" {
+		details = append(details, model.Details.ParameterSize)
+	}
+	if model.Details.QuantizationLevel != "" {
+		details = append(details, model.Details.QuantizationLevel)
+	}
+	if contextWindow > 0 {
+		details = append(details, strconv.Itoa(contextWindow)+" context")
+	}
+	return strings.Join(details, ", ")
+}
+
+// the context window of the API settings caps the one the model was trained with,
+// and is the context Ollama is asked to run the model with
+func (p *ollamaProvider) ListModels(ctx context.Context) ([]ProviderModel, error) {
+	installed, err := p.installedModels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var providerModels []ProviderModel
+	for _, model := range installed {
+		contextWindow := p.contextLength(ctx, model)
+		if limit := p.apiRecord.GetInt("context_window"); limit > 0 && (contextWindow == 0 || limit < contextWindow) {
+			contextWindow = limit
+		}
+		providerModels = append(providerModels, ProviderModel{
+			Id:            model.Name,
+			ContextWindow: contextWindow,
+			Details:       ollamaModelDetails(model, contextWindow),
+		})
+	}
+	return providerModels, nil
+}
+
+type ollamaToolCall struct {
+	Function struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	} `json:"function"`
+}
+
+type ollamaMessage struct {
+	Role      string           `json:"role"`
+	Content   string           `json:"content"`
+	Images    []string         `json:"images,omitempty"`
+	ToolCalls []ollamaToolCall `json:"tool_calls,omitempty"`
+	// set by thinking models apart from the content
+	Thinking string `json:"thinking,omitempty"`
+}
+
+type ollamaChatRequest struct {
+	Model    string          `json:"model"`
+	Messages []ollamaMessage `json:"messages"`
+	Tools    []openai.Tool   `json:"tools,omitempty"`
+	Options  map[string]any  `json:"options,omitempty"`
+	Stream   bool            `json:"stream"`
+}
+
+// one line of a chat response, the last one has done set and the token counts
+type ollamaChatChunk struct {
+	Message         ollamaMessage `json:"message"`
+	Done            bool          `json:"done"`
+	DoneReason      string        `json:"done_reason"`
+	PromptEvalCount int           `json:"prompt_eval_count"`
+	EvalCount       int           `json:"eval_count"`
+	Error           string        `json:"error"`
+}
+
+func ollamaMessageFrom(message openai.ChatCompletionMessage) ollamaMessage {
+	converted := ollamaMessage{Role: message.Role, Content: message.Content}
+	if len(message.MultiContent) > 0 {
+		var text []string
+		for _, part := range message.MultiContent {
+			if part.Type == openai.ChatMessagePartTypeText {
+				text = append(text, part.Text)
+			} else if part.ImageURL != nil {
+				// images are sent as plain base64, only attachments come as data URLs
+				if _, data, ok := strings.Cut(part.ImageURL.URL, ";base64,"); ok {
+					converted.Images = append(converted.Images, data)
+				}
+			}
+		}
+		converted.Content = strings.Join(text, "\n")
+	}
+
+	for _, call := range message.ToolCalls {
+		var toolCall ollamaToolCall
+		toolCall.Function.Name = call.Function.Name
+		toolCall.Function.Arguments = json.RawMessage(call.Function.Arguments)
+		if !json.Valid(toolCall.Function.Arguments) {
+			toolCall.Function.Arguments = json.RawMessage("{}")
+		}
+		converted.ToolCalls = append(converted.ToolCalls, toolCall)
+	}
+	return converted
+}
+
+func (p *ollamaProvider) chatRequest(req openai.ChatCompletionRequest, stream bool) ollamaChatRequest {
+	request := ollamaChatRequest{
+		Model:   req.Model,
+		Tools:   req.Tools,
+		Options: make(map[string]any),
+		Stream:  stream,
+	}
+	for _, message := range req.Messages {
+		request.Messages = append(request.Messages, ollamaMessageFrom(message))
+	}
+
+	if temperature := requestFloat(req.Temperature); temperature != nil {
+		request.Options["temperature"] = *temperature
+	}
+	if topP := requestFloat(req.TopP); topP != nil {
+		request.Options["top_p"] = *topP
+	}
+	if req.MaxTokens > 0 {
+		request.Options["num_predict"] = req.MaxTokens
+	}
+	if req.PresencePenalty != 0 {
+		request.Options["presence_penalty"] = req.PresencePenalty
+	}
+	if req.FrequencyPenalty != 0 {
+		request.Options["frequency_penalty"] = req.FrequencyPenalty
+	}
+	if len(req.Stop) > 0 {
+		request.Options["stop"] = req.Stop
+	}
+	if req.Seed != nil {
+		request.Options["seed"] = *req.Seed
+	}
+	// Ollama sets aside memory for the whole context it is asked for, so a longer one than
+	// its default is only asked for when set in the API settings, never the model's full length
+	if p.apiRecord.GetInt("context_window") > 0 {
+		request.Options["num_ctx"] = contextWindowLimit(p.apiRecord, req.Model)
+	}
+	return request
+}
+
+// the chunk as go-openai would have streamed it
+func (chunk ollamaChatChunk) streamResponse(toolCalls int) openai.ChatCompletionStreamResponse {
+	delta := openai.ChatCompletionStreamChoiceDelta{Content: chunk.Message.Content}
+	// each call arrives whole, so they are numbered in the order they come
+	for i, call := range chunk.Message.ToolCalls {
+		index := toolCalls + i
+		delta.ToolCalls = append(delta.ToolCalls, openai.ToolCall{
+			Index:    &index,
+			Type:     openai.ToolTypeFunction,
+			Function: openai.FunctionCall{Name: call.Function.Name, Arguments: string(call.Function.Arguments)},
+		})
+	}
+
+	var finishReason openai.FinishReason
+	if chunk.Done {
+		finishReason = ollamaFinishReason(chunk.DoneReason, toolCalls+len(chunk.Message.ToolCalls) > 0)
+	}
+	return openai.ChatCompletionStreamResponse{
+		Choices: []openai.ChatCompletionStreamChoice{{Delta: delta, FinishReason: finishReason}},
+	}
+}
+
+func ollamaFinishReason(doneReason string, calledTools bool) openai.FinishReason {
+	switch {
+	case calledTools:
+		return openai.FinishReasonToolCalls
+	case doneReason == "length":
+		return openai.FinishReasonLength
+	}
+	return openai.FinishReasonStop
+}
+
+func (p *ollamaProvider) Chat(ctx context.Context, req openai.ChatCompletionRequest) (openai.ChatCompletionResponse, error) {
+	response, err := p.do(ctx, http.MethodPost, "/api/chat", p.chatRequest(req, false))
+	if err != nil {
+		return openai.ChatCompletionResponse{}, err
+	}
+	defer response.Body.Close()
+
+	var chunk ollamaChatChunk
+	if err := json.NewDecoder(response.Body).Decode(&chunk); err != nil {
+		return openai.ChatCompletionResponse{}, fmt.Errorf("failed to read Ollama response: %w", err)
+	}
+
+	message := openai.ChatCompletionMessage{Role: openai.ChatMessageRoleAssistant, Content: chunk.Message.Content}
+	for _, call := range chunk.streamResponse(0).Choices[0].Delta.ToolCalls {
+		call.Index = nil
+		message.ToolCalls = append(message.ToolCalls, call)
+	}
+	return openai.ChatCompletionResponse{
+		Model: req.Model,
+		Choices: []openai.ChatCompletionChoice{{
+			Message:      message,
+			FinishReason: ollamaFinishReason(chunk.DoneReason, len(message.ToolCalls) > 0),
+		}},
+		Usage: openai.Usage{
+			PromptTokens:     chunk.PromptEvalCount,
+			CompletionTokens: chunk.EvalCount,
+			TotalTokens:      chunk.PromptEvalCount + chunk.EvalCount,
+		},
+	}, nil
+}
+
+func (p *ollamaProvider) StreamChat(ctx context.Context, req openai.ChatCompletionRequest) (ChatStream, error) {
+	response, err := p.do(ctx, http.MethodPost, "/api/chat", p.chatRequest(req, true))
+	if err != nil {
+		return nil, err
+	}
+	return &ollamaStream{body: response.Body, reader: bufio.NewReader(response.Body)}, nil
+}
+
+// reads the NDJSON lines of a chat response as OpenAI chunks
+type ollamaStream struct {
+	body          io.ReadCloser
+	reader        *bufio.Reader
+	toolCalls     int
+	usage         *openai.Usage
+	done          bool
+	lastReasoning string
+}
+
+// the next JSON line, empty lines are skipped
+func readNDJSON(reader *bufio.Reader, value any) error {
+	for {
+		line, err := reader.ReadBytes('\n')
+		line = bytes.TrimSpace(line)
+		if len(line) > 0 {
+			return json.Unmarshal(line, value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *ollamaStream) Recv() (openai.ChatCompletionStreamResponse, error) {
+	if s.done {
+		return openai.ChatCompletionStreamResponse{}, io.EOF
+	}
+
+	var chunk ollamaChatChunk
+	if err := readNDJSON(s.reader, &chunk); err != nil {
+		// the last line always has done set, a stream ending before it was cut off
+		if errors.Is(err, io.EOF) {
+			return openai.ChatCompletionStreamResponse{}, io.ErrUnexpectedEOF
+		}
+		return openai.ChatCompletionStreamResponse{}, err
+	}
+	if chunk.Error != "" {
+		return openai.ChatCompletionStreamResponse{}, &openai.APIError{Message: chunk.Error, HTTPStatusCode: http.StatusInternalServerError}
+	}
+
+	response := chunk.streamResponse(s.toolCalls)
+	s.toolCalls += len(chunk.Message.ToolCalls)
+	s.lastReasoning = chunk.Message.Thinking
+	if chunk.Done {
+		s.done = true
+		s.usage = &openai.Usage{
+			PromptTokens:     chunk.PromptEvalCount,
+			CompletionTokens: chunk.EvalCount,
+			TotalTokens:      chunk.PromptEvalCount + chunk.EvalCount,
+		}
+	}
+	return response, nil
+}
+
+func (s *ollamaStream) Reasoning() string {
+	return s.lastReasoning
+}
+
+func (s *ollamaStream) Close() error {
+	return s.body.Close()
+}
+
+func (s *ollamaStream) Usage() *openai.Usage {
+	return s.usage
+}
+
+func (p *ollamaProvider) Embed(ctx context.Context, model string, input []string) ([][]float32, error) {
+	response, err := p.do(ctx, http.MethodPost, "/api/embed", map[string]any{"model": model, "input": input})
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	var data struct {
+		Embeddings [][]float32 `json:"embeddings"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to read Ollama embeddings: %w", err)
+	}
+	return data.Embeddings, nil
+}
+
+// progress of a model download, sent line by line while pulling
+type ollamaPullStatus struct {
+	Status    string `json:"status"`
+	Total     int64  `json:"total"`
+	Completed int64  `json:"completed"`
+	Error     string `json:"error"`
+}
+
+// download a model, progress is called with each status the server sends
+func (p *ollamaProvider) pull(ctx context.Context, model string, progress func(ollamaPullStatus)) error {
+	response, err := p.do(ctx, http.MethodPost, "/api/pull", map[string]any{"model": model, "stream": true})
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	reader := bufio.NewReader(response.Body)
+	for {
+		var status ollamaPullStatus
+		if err := readNDJSON(reader, &status); err != nil {
+			if errors.Is(err, io.EOF) {
+				return io.ErrUnexpectedEOF
+			}
+			return err
+		}
+		if status.Error != "" {
+			return errors.New(status.Error)
+		}
+		progress(status)
+		if status.Status == "success" {
+			return nil
+		}
+	}
+}
+
+func (p *ollamaProvider) delete(ctx context.Context, model string) error {
+	response, err := p.do(ctx, http.MethodDelete, "/api/delete", map[string]any{"model": model})
+	if err != nil {
+		return err
+	}
+	response.Body.Close()
+
+	// the context windows of the remaining models are still right, only forget the deleted one
+	modelContextWindows.Delete(p.apiRecord.Id + "/" + model)
+	return nil
+}
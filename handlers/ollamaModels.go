@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/erikmillergalow/htmx-llmchat/templates"
+
+	"github.com/labstack/echo/v5"
+
+	"github.com/pocketbase/pocketbase"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// how long the server is waited for in the API editor
+const ollamaListTimeout = 10 * time.Second
+
+func renderOllamaModels(provider *ollamaProvider, c echo.Context) error {
+	ctx, cancel := context.WithTimeout(context.Background(), ollamaListTimeout)
+	defer cancel()
+
+	var params []templates.OllamaModelParams
+	errMsg := ""
+	installed, err := provider.installedModels(ctx)
+	if err != nil {
+		fmt.Printf("Failed to list Ollama models: %v\n", err)
+		errMsg = "Unable to reach the Ollama server"
+	}
+	for _, model := range installed {
+		params = append(params, templates.OllamaModelParams{
+			Name:    model.Name,
+			Size:    formatBytes(model.Size),
+			Details: ollamaModelDetails(model, 0),
+		})
+	}
+
+	c.Response().Writer.WriteHeader(200)
+	modelsList := templates.OllamaModelsList(provider.apiRecord.Id, params, errMsg)
+	err = modelsList.Render(context.Background(), c.Response().Writer)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to render Ollama models")
+	}
+
+	return nil
+}
+
+func GetOllamaModels(id string, c echo.Context, app *pocketbase.PocketBase) error {
+	apiRecord, err := app.Dao().FindRecordById("apis", id)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to find api record for Ollama models")
+	}
+	provider, err := ollamaProviderFor(apiRecord)
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	return renderOllamaModels(provider, c)
+}
+
+// start downloading a model, progress is sent to every open chat socket
+func PullOllamaModel(id string, data map[string]any, c echo.Context, app *pocketbase.PocketBase) error {
+	apiRecord, err := app.Dao().FindRecordById("apis", id)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to find api record for Ollama pull")
+	}
+	provider, err := ollamaProviderFor(apiRecord)
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	model := strings.TrimSpace(FormValue(data, "ollama-model"))
+	if model == "" {
+		return c.NoContent(http.StatusNoContent)
+	}
+
+	pull := templates.OllamaPullParams{
+		Id:     security.RandomString(10),
+		ApiId:  apiRecord.Id,
+		Model:  model,
+		Status: "starting",
+	}
+	go pullOllamaModel(provider, pull)
+
+	c.Response().Writer.WriteHeader(200)
+	progress := templates.OllamaPullProgress(pull, false)
+	err = progress.Render(context.Background(), c.Response().Writer)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to render Ollama pull")
+	}
+
+	return nil
+}
+
+// how often the progress of a layer is sent, a new status is always sent right away
+const ollamaPullUpdateInterval = 250 * time.Millisecond
+
+func pullOllamaModel(provider *ollamaProvider, pull templates.OllamaPullParams) {
+	lastUpdate := time.Time{}
+	err := provider.pull(context.Background(), pull.Model, func(status ollamaPullStatus) {
+		if status.Status == pull.Status && time.Since(lastUpdate) < ollamaPullUpdateInterval {
+			return
+		}
+		lastUpdate = time.Now()
+
+		pull.Status = status.Status
+		pull.Completed = status.Completed
+		pull.Total = status.Total
+		pull.Done = status.Status == "success"
+		generationJobs.broadcast(templates.OllamaPullProgress(pull, true))
+	})
+	if err != nil {
+		fmt.Printf("Failed to pull Ollama model %s: %v\n", pull.Model, err)
+		pull.Error = "Failed to pull, " + err.Error()
+		generationJobs.broadcast(templates.OllamaPullProgress(pull, true))
+	}
+}
+
+func DeleteOllamaModel(id string, model string, c echo.Context, app *pocketbase.PocketBase) error {
+	apiRecord, err := app.Dao().FindRecordById("apis", id)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to find api record for Ollama delete")
+	}
+	provider, err := ollamaProviderFor(apiRecord)
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), ollamaListTimeout)
+	defer cancel()
+	if err := provider.delete(ctx, model); err != nil {
+		fmt.Printf("Failed to delete Ollama model %s: %v\n", model, err)
+		return c.String(http.StatusInternalServerError, "failed to delete Ollama model")
+	}
+
+	c.Response().Header().Set("HX-Trigger", "refresh-models")
+	return renderOllamaModels(provider, c)
+}
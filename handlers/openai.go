@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	openai "github.com/sashabaranov/go-openai"
+
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// types for parsing API /models endpoint response
+type ApiNamesResponse struct {
+	Object string     `json:"object"`
+	Data   []ApiModel `json:"data"`
+}
+
+type ApiModel struct {
+	Id            string `json:"id"`
+	Object        string `json:"object"`
+	Created       int64  `json:"created"`
+	OwnedBy       string `json:"owned_by"`
+	Active        bool   `json:"active"`
+	ContextWindow int    `json:"context_window"`
+	PublicApps    any    `json:"public_apps"`
+}
+
+// OpenAI and the many servers that copy its API: Groq, llama.cpp, vLLM, LM Studio...
+type openAIProvider struct {
+	apiRecord *models.Record
+}
+
+func newOpenAIProvider(apiRecord *models.Record) Provider {
+	return &openAIProvider{apiRecord: apiRecord}
+}
+
+// streamed usage is collected into usage when given, unless the API rejects the option
+// failed responses report their Retry-After to a hint in the request context
+// reasoning sent in its own delta field is collected into reasoning when given
+func (p *openAIProvider) client(usage *streamUsage, reasoning *streamReasoning) *openai.Client {
+	config := openai.DefaultConfig(p.apiRecord.GetString("api_key"))
+	config.BaseURL = p.apiRecord.GetString("url")
+
+	var transport http.RoundTripper = &retryAfterTransport{base: http.DefaultTransport}
+	if usage != nil && !p.apiRecord.GetBool("skip_stream_usage") {
+		transport = &streamUsageTransport{base: transport, usage: usage}
+	}
+	if reasoning != nil {
+		transport = &streamReasoningTransport{base: transport, reasoning: reasoning}
+	}
+	config.HTTPClient = &http.Client{Transport: transport}
+	return openai.NewClientWithConfig(config)
+}
+
+// read with a plain request, go-openai drops the context window some servers list
+func (p *openAIProvider) ListModels(ctx context.Context) ([]ProviderModel, error) {
+	listModelsUrl := p.apiRecord.GetString("url") + "/models"
+	request, err := http.NewRequestWithContext(ctx, "GET", listModelsUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Add("Authorization", "Bearer "+p.apiRecord.GetString("api_key"))
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read models endpoint response: %w", err)
+	}
+
+	var data ApiNamesResponse
+	err = json.Unmarshal(body, &data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read models endpoint response: %w", err)
+	}
+
+	var providerModels []ProviderModel
+	for _, model := range data.Data {
+		providerModels = append(providerModels, ProviderModel{Id: model.Id, ContextWindow: model.ContextWindow})
+	}
+	return providerModels, nil
+}
+
+type openAIStream struct {
+	*openai.ChatCompletionStream
+	usage     *streamUsage
+	reasoning *streamReasoning
+	// chunks received so far
+	chunks        int
+	lastReasoning string
+}
+
+func (s *openAIStream) Recv() (openai.ChatCompletionStreamResponse, error) {
+	response, err := s.ChatCompletionStream.Recv()
+	s.lastReasoning = ""
+	if err == nil {
+		s.lastReasoning = s.reasoning.take(s.chunks)
+		s.chunks++
+	}
+	return response, err
+}
+
+func (s *openAIStream) Reasoning() string {
+	return s.lastReasoning
+}
+
+func (s *openAIStream) Usage() *openai.Usage {
+	return s.usage.get()
+}
+
+// APIs with a prompt format serve base models through the text completion endpoint
+func (p *openAIProvider) StreamChat(ctx context.Context, req openai.ChatCompletionRequest) (ChatStream, error) {
+	if p.apiRecord.GetString("prompt_format") != "" {
+		return p.streamCompletion(ctx, req)
+	}
+
+	usage := &streamUsage{}
+	reasoning := &streamReasoning{}
+	stream, err := p.client(usage, reasoning).CreateChatCompletionStream(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &openAIStream{ChatCompletionStream: stream, usage: usage, reasoning: reasoning}, nil
+}
+
+func (p *openAIProvider) Chat(ctx context.Context, req openai.ChatCompletionRequest) (openai.ChatCompletionResponse, error) {
+	if p.apiRecord.GetString("prompt_format") != "" {
+		return p.completion(ctx, req)
+	}
+	return p.client(nil, nil).CreateChatCompletion(ctx, req)
+}
+
+func (p *openAIProvider) Embed(ctx context.Context, model string, input []string) ([][]float32, error) {
+	response, err := p.client(nil, nil).CreateEmbeddings(ctx, openai.EmbeddingRequest{
+		Input: input,
+		Model: openai.EmbeddingModel(model),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	embeddings := make([][]float32, len(input))
+	for _, embedding := range response.Data {
+		if embedding.Index >= 0 && embedding.Index < len(embeddings) {
+			embeddings[embedding.Index] = embedding.Embedding
+		}
+	}
+	return embeddings, nil
+}
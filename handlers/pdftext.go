@@ -0,0 +1,233 @@
+package handlers
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/hex"
+	"io"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// the stream keyword follows its dictionary, which leaves out the end of "endstream"
+var pdfStreamStart = regexp.MustCompile(`(?:>>|\s)(stream\r?\n)`)
+
+// best effort text of a PDF, read from the text operators of its content streams
+// fonts with custom encodings come out garbled and are dropped by the printable filter
+func extractPdfText(data []byte) string {
+	var text strings.Builder
+
+	for _, match := range pdfStreamStart.FindAllSubmatchIndex(data, -1) {
+		loc := match[2:4]
+		// the stream dictionary comes right before the stream keyword
+		dictStart := bytes.LastIndex(data[:loc[0]], []byte("<<"))
+		if dictStart < 0 {
+			continue
+		}
+		dict := data[dictStart:loc[0]]
+		if bytes.Contains(dict, []byte("/Image")) || bytes.Contains(dict, []byte("/FontFile")) {
+			continue
+		}
+
+		end := bytes.Index(data[loc[1]:], []byte("endstream"))
+		if end < 0 {
+			break
+		}
+		content := data[loc[1] : loc[1]+end]
+
+		if bytes.Contains(dict, []byte("/FlateDecode")) {
+			reader, err := zlib.NewReader(bytes.NewReader(content))
+			if err != nil {
+				continue
+			}
+			// truncated streams still give up what was decoded before the error
+			content, _ = io.ReadAll(io.LimitReader(reader, 64<<20))
+			reader.Close()
+		} else if bytes.Contains(dict, []byte("/Filter")) {
+			continue
+		}
+
+		text.WriteString(pdfContentText(content))
+	}
+
+	return strings.TrimSpace(text.String())
+}
+
+// text shown by Tj, TJ, ' and " in a content stream
+func pdfContentText(content []byte) string {
+	var text strings.Builder
+	var operands []string
+	var line strings.Builder
+
+	flush := func() {
+		if s := strings.TrimSpace(line.String()); s != "" {
+			text.WriteString(s + "\n")
+		}
+		line.Reset()
+	}
+
+	for i := 0; i < len(content); {
+		c := content[i]
+		switch {
+		case c == '(':
+			s, next := pdfLiteralString(content, i)
+			operands = append(operands, s)
+			i = next
+		case c == '<' && i+1 < len(content) && content[i+1] != '<':
+			end := bytes.IndexByte(content[i:], '>')
+			if end < 0 {
+				return text.String()
+			}
+			operands = append(operands, pdfHexString(content[i+1:i+end]))
+			i += end + 1
+		case c == '[':
+			// TJ arrays mix strings with kerning, large gaps stand for spaces
+			end := i + 1
+			var parts strings.Builder
+			for end < len(content) && content[end] != ']' {
+				switch {
+				case content[end] == '(':
+					s, next := pdfLiteralString(content, end)
+					parts.WriteString(s)
+					end = next
+				case content[end] == '<':
+					close := bytes.IndexByte(content[end:], '>')
+					if close < 0 {
+						end = len(content)
+						break
+					}
+					parts.WriteString(pdfHexString(content[end+1 : end+close]))
+					end += close + 1
+				case content[end] == '-' || (content[end] >= '0' && content[end] <= '9'):
+					start := end
+					for end < len(content) && (content[end] == '-' || content[end] == '.' || (content[end] >= '0' && content[end] <= '9')) {
+						end++
+					}
+					if strings.HasPrefix(string(content[start:end]), "-") && len(content[start:end]) > 3 {
+						parts.WriteString(" ")
+					}
+				default:
+					end++
+				}
+			}
+			operands = append(operands, parts.String())
+			i = end + 1
+		case c == '%':
+			for i < len(content) && content[i] != '\n' && content[i] != '\r' {
+				i++
+			}
+		case unicode.IsLetter(rune(c)) || c == '\'' || c == '"' || c == '*':
+			start := i
+			for i < len(content) && (unicode.IsLetter(rune(content[i])) || content[i] == '*' || content[i] == '\'' || content[i] == '"') {
+				i++
+			}
+			switch string(content[start:i]) {
+			case "Tj", "TJ":
+				for _, operand := range operands {
+					line.WriteString(operand)
+				}
+			case "'", "\"":
+				flush()
+				for _, operand := range operands {
+					line.WriteString(operand)
+				}
+			case "T*", "Td", "TD", "ET":
+				flush()
+			}
+			operands = operands[:0]
+		default:
+			i++
+		}
+	}
+	flush()
+
+	return pdfPrintable(text.String())
+}
+
+// a (literal string) starting at start, with the index after its closing parenthesis
+func pdfLiteralString(content []byte, start int) (string, int) {
+	var s strings.Builder
+	depth := 0
+	for i := start; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case c == '\\' && i+1 < len(content):
+			i++
+			switch content[i] {
+			case 'n':
+				s.WriteByte('\n')
+			case 'r', 't':
+				s.WriteByte(' ')
+			case '(', ')', '\\':
+				s.WriteByte(content[i])
+			default:
+				// octal escapes
+				if content[i] >= '0' && content[i] <= '7' {
+					value := 0
+					j := i
+					for ; j < len(content) && j < i+3 && content[j] >= '0' && content[j] <= '7'; j++ {
+						value = value*8 + int(content[j]-'0')
+					}
+					s.WriteRune(rune(value))
+					i = j - 1
+				}
+			}
+		case c == '(':
+			if depth > 0 {
+				s.WriteByte(c)
+			}
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return s.String(), i + 1
+			}
+			s.WriteByte(c)
+		default:
+			s.WriteByte(c)
+		}
+	}
+	return s.String(), len(content)
+}
+
+func pdfHexString(digits []byte) string {
+	cleaned := bytes.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, digits)
+	if len(cleaned)%2 == 1 {
+		cleaned = append(cleaned, '0')
+	}
+	decoded, err := hex.DecodeString(string(cleaned))
+	if err != nil {
+		return ""
+	}
+	return string(decoded)
+}
+
+// keep lines that are mostly readable text
+func pdfPrintable(text string) string {
+	var kept []string
+	for _, line := range strings.Split(text, "\n") {
+		if !utf8.ValidString(line) {
+			line = strings.ToValidUTF8(line, "")
+		}
+		printable := 0
+		for _, r := range line {
+			if unicode.IsPrint(r) {
+				printable++
+			}
+		}
+		if count := utf8.RuneCountInString(line); count > 0 && printable*10 >= count*9 {
+			kept = append(kept, line)
+		}
+	}
+	if len(kept) == 0 {
+		return ""
+	}
+	return strings.Join(kept, "\n") + "\n"
+}
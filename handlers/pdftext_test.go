@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"testing"
+)
+
+// a PDF body with one object per stream, only what extractPdfText looks at
+func testPdf(streams ...string) []byte {
+	var pdf bytes.Buffer
+	pdf.WriteString("%PDF-1.4\n")
+	for i, stream := range streams {
+		fmt.Fprintf(&pdf, "%d 0 obj\n%s\nendstream\nendobj\n", i+1, stream)
+	}
+	pdf.WriteString("trailer\n<< /Root 1 0 R >>\n%%EOF\n")
+	return pdf.Bytes()
+}
+
+func plainStream(content string) string {
+	return fmt.Sprintf("<< /Length %d >>\nstream\n%s", len(content), content)
+}
+
+func TestExtractPdfTextStreams(t *testing.T) {
+	var compressed bytes.Buffer
+	writer := zlib.NewWriter(&compressed)
+	writer.Write([]byte("BT /F1 12 Tf (Compressed) Tj ET"))
+	writer.Close()
+
+	pdf := testPdf(
+		plainStream("BT /F1 12 Tf 72 720 Td (Hello) Tj ET"),
+		plainStream("BT /F1 12 Tf 72 700 Td [(Wor) 20 (ld)] TJ T* <416761696e> Tj ET"),
+		fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>stream\r\n%s", compressed.Len(), compressed.String()),
+		"<< /Type /XObject /Subtype /Image /Length 9 >>\nstream\n(Image) Tj",
+		"<< /Length 14 /Filter /DCTDecode >>\nstream\n(Encoded) Tj",
+	)
+
+	want := "Hello\nWorld\nAgain\nCompressed"
+	if got := extractPdfText(pdf); got != want {
+		t.Errorf("extractPdfText = %q, want %q", got, want)
+	}
+}
+
+func TestPdfContentText(t *testing.T) {
+	tests := []struct {
+		content string
+		want    string
+	}{
+		{"BT (Hello \\(world\\)) Tj ET", "Hello (world)\n"},
+		{"BT [(Spaced) -250 (out)] TJ ET", "Spaced out\n"},
+		{"BT (one) Tj (two) ' ET", "one\ntwo\n"},
+		{"BT (caf\\351) Tj ET", "café\n"},
+		{"% a comment (not text) Tj\nBT (kept) Tj ET", "kept\n"},
+		{"BT <00010203> Tj ET", ""},
+	}
+	for _, test := range tests {
+		if got := pdfContentText([]byte(test.content)); got != test.want {
+			t.Errorf("pdfContentText(%q) = %q, want %q", test.content, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/erikmillergalow/htmx-llmchat/templates"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+var (
+	promptVariable = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_]+)\s*\}\}`)
+	promptName     = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+	// a message starting with /name, the rest of it is kept
+	promptCommand = regexp.MustCompile(`(?s)^/([A-Za-z0-9_-]+)(?:\s+(.*))?$`)
+)
+
+// variables of a prompt template, in the order they first appear
+func templateVariables(template string) []string {
+	var variables []string
+	for _, match := range promptVariable.FindAllStringSubmatch(template, -1) {
+		if !slices.Contains(variables, match[1]) {
+			variables = append(variables, match[1])
+		}
+	}
+	return variables
+}
+
+// variable values sent with a message, from inputs named prompt-var-<name>
+func promptVariables(msg []byte) map[string]string {
+	var fields map[string]any
+	if err := json.Unmarshal(msg, &fields); err != nil {
+		return nil
+	}
+
+	variables := make(map[string]string)
+	for key, value := range fields {
+		name, ok := strings.CutPrefix(key, "prompt-var-")
+		if !ok {
+			continue
+		}
+		if text, ok := value.(string); ok {
+			variables[name] = text
+		}
+	}
+	return variables
+}
+
+// expand a message starting with /name into the prompt template of that name
+// text after the name fills the first variable left empty, or follows the prompt if there is none
+// returns the message to send and the fields recording the template it came from
+func expandPromptCommand(message string, variables map[string]string, app *pocketbase.PocketBase) (string, map[string]any, error) {
+	match := promptCommand.FindStringSubmatch(strings.TrimSpace(message))
+	if match == nil {
+		return message, nil, nil
+	}
+
+	// a message that only looks like a command is sent as typed
+	promptRecord, err := app.Dao().FindFirstRecordByData("prompts", "name", match[1])
+	if err != nil {
+		return message, nil, nil
+	}
+
+	rest := strings.TrimSpace(match[2])
+	used := make(map[string]string)
+	var missing []string
+	for _, variable := range templateVariables(promptRecord.GetString("template")) {
+		value := strings.TrimSpace(variables[variable])
+		if value == "" && rest != "" {
+			value, rest = rest, ""
+		}
+		if value == "" {
+			missing = append(missing, variable)
+		}
+		used[variable] = value
+	}
+	if len(missing) > 0 {
+		return "", nil, fmt.Errorf("/%s needs a value for %s", match[1], strings.Join(missing, ", "))
+	}
+
+	expanded := promptVariable.ReplaceAllStringFunc(promptRecord.GetString("template"), func(placeholder string) string {
+		return used[promptVariable.FindStringSubmatch(placeholder)[1]]
+	})
+	if rest != "" {
+		expanded += "\n\n" + rest
+	}
+
+	return expanded, map[string]any{
+		"prompt_id":        promptRecord.Id,
+		"prompt_name":      promptRecord.GetString("name"),
+		"prompt_variables": used,
+	}, nil
+}
+
+// open the prompt library in the sidebar
+func OpenPromptEditor(c echo.Context, app *pocketbase.PocketBase) error {
+	var promptParams []templates.PromptParams
+
+	app.Dao().DB().
+		Select("*").
+		From("prompts").
+		OrderBy("created DESC").
+		All(&promptParams)
+
+	c.Response().Writer.WriteHeader(200)
+	promptEditor := templates.PromptEditorsList(promptParams)
+	err := promptEditor.Render(context.Background(), c.Response().Writer)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to render prompt editor")
+	}
+
+	return nil
+}
+
+// create new prompt template
+func CreatePrompt(c echo.Context, app *pocketbase.PocketBase) error {
+	promptsCollection, err := app.Dao().FindCollectionByNameOrId("prompts")
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to read prompts DB")
+	}
+
+	newPromptRecord := models.NewRecord(promptsCollection)
+	form := forms.NewRecordUpsert(app, newPromptRecord)
+	form.LoadData(map[string]any{
+		"name":     "",
+		"template": "",
+	})
+	if err := form.Submit(); err != nil {
+		return c.String(http.StatusInternalServerError, "failed to create new prompt DB entry")
+	}
+
+	c.Response().Writer.WriteHeader(200)
+	newPrompt := templates.NewPromptEditor(templates.PromptParams{Id: newPromptRecord.Id})
+	err = newPrompt.Render(context.Background(), c.Response().Writer)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to render new prompt DB entry")
+	}
+
+	return nil
+}
+
+func renderPromptUpdateResult(msg string, c echo.Context) error {
+	promptUpdateResult := templates.PromptUpdateResult(msg)
+	err := promptUpdateResult.Render(context.Background(), c.Response().Writer)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to render prompt update result")
+	}
+
+	return nil
+}
+
+// update prompt template, names are unique so /name always finds one prompt
+func UpdatePrompt(id string, data map[string]any, c echo.Context, app *pocketbase.PocketBase) error {
+	promptRecord, err := app.Dao().FindRecordById("prompts", id)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to find prompt record")
+	}
+
+	name := strings.TrimPrefix(strings.TrimSpace(FormValue(data, "name")), "/")
+	if !promptName.MatchString(name) {
+		return renderPromptUpdateResult("Names can only use letters, numbers, - and _", c)
+	}
+	if existing, err := app.Dao().FindFirstRecordByData("prompts", "name", name); err == nil && existing.Id != id {
+		return renderPromptUpdateResult("Another prompt is already named /"+name, c)
+	}
+
+	promptRecord.Set("name", name)
+	promptRecord.Set("template", FormValue(data, "template"))
+	if err := app.Dao().SaveRecord(promptRecord); err != nil {
+		return c.String(http.StatusInternalServerError, "failed to update prompt record")
+	}
+
+	return renderPromptUpdateResult("Prompt updated!", c)
+}
+
+// delete prompt template, messages expanded from it keep its name
+func DeletePrompt(id string, c echo.Context, app *pocketbase.PocketBase) error {
+	record, err := app.Dao().FindRecordById("prompts", id)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to retrieve prompt record for deletion")
+	}
+
+	if err := app.Dao().DeleteRecord(record); err != nil {
+		return c.String(http.StatusInternalServerError, "failed to delete prompt record")
+	}
+
+	c.Response().Writer.WriteHeader(200)
+	deletedPrompt := templates.DeletedPrompt()
+	err = deletedPrompt.Render(context.Background(), c.Response().Writer)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to render deleted prompt")
+	}
+
+	return nil
+}
+
+// list prompts while a /command is being typed, nothing once the name is followed by a space
+func SuggestPrompts(message string, c echo.Context, app *pocketbase.PocketBase) error {
+	var promptParams []templates.PromptParams
+
+	if partial, ok := strings.CutPrefix(message, "/"); ok && !strings.ContainsAny(partial, " \t\n") {
+		app.Dao().DB().
+			Select("*").
+			From("prompts").
+			Where(dbx.Like("name", partial).Match(false, true)).
+			AndWhere(dbx.NewExp("name != ''")).
+			OrderBy("name ASC").
+			Limit(10).
+			All(&promptParams)
+	}
+
+	c.Response().Writer.WriteHeader(200)
+	suggestions := templates.PromptSuggestions(promptParams)
+	err := suggestions.Render(context.Background(), c.Response().Writer)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to render prompt suggestions")
+	}
+
+	return nil
+}
+
+// inputs for the variables of a prompt picked from the suggestions
+func OpenPromptVariables(id string, c echo.Context, app *pocketbase.PocketBase) error {
+	promptRecord, err := app.Dao().FindRecordById("prompts", id)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to find prompt record")
+	}
+
+	params := templates.PromptParams{
+		Id:        promptRecord.Id,
+		Name:      promptRecord.GetString("name"),
+		Template:  promptRecord.GetString("template"),
+		Variables: templateVariables(promptRecord.GetString("template")),
+	}
+
+	c.Response().Writer.WriteHeader(200)
+	variables := templates.PromptVariables(params)
+	err = variables.Render(context.Background(), c.Response().Writer)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to render prompt variables")
+	}
+
+	return nil
+}
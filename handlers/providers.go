@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/erikmillergalow/htmx-llmchat/templates"
+
+	openai "github.com/sashabaranov/go-openai"
+
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// a backend an API record talks to, picked by the type field of the API
+// requests and responses use the OpenAI shapes, other backends translate them
+type Provider interface {
+	// models the API serves
+	ListModels(ctx context.Context) ([]ProviderModel, error)
+	// stream a chat completion chunk by chunk
+	StreamChat(ctx context.Context, req openai.ChatCompletionRequest) (ChatStream, error)
+	// a whole chat completion, for titles and summaries
+	Chat(ctx context.Context, req openai.ChatCompletionRequest) (openai.ChatCompletionResponse, error)
+}
+
+// implemented by providers that can embed text
+type EmbeddingProvider interface {
+	Embed(ctx context.Context, model string, input []string) ([][]float32, error)
+}
+
+type ProviderModel struct {
+	Id string
+	// 0 when the API doesn't report it
+	ContextWindow int
+	// shown next to the name in the model select
+	Details string
+}
+
+type ChatStream interface {
+	// the next chunk, io.EOF once the response is complete
+	Recv() (openai.ChatCompletionStreamResponse, error)
+	Close() error
+	// usage the API reported with the stream, nil if it didn't
+	Usage() *openai.Usage
+	// thinking that came with the last chunk, for APIs that send it apart from the content
+	Reasoning() string
+}
+
+type providerType struct {
+	Type string
+	// shown in the API editor
+	Name string
+	New  func(apiRecord *models.Record) Provider
+}
+
+// backends an API can use, the first one is the default
+var providerTypes = []providerType{
+	{Type: "openai", Name: "OpenAI compatible", New: newOpenAIProvider},
+	{Type: "anthropic", Name: "Anthropic", New: newAnthropicProvider},
+	{Type: "ollama", Name: "Ollama", New: newOllamaProvider},
+}
+
+func findProviderType(name string) (providerType, bool) {
+	// APIs from before there were types are OpenAI compatible
+	if name == "" {
+		return providerTypes[0], true
+	}
+	for _, providerType := range providerTypes {
+		if providerType.Type == name {
+			return providerType, true
+		}
+	}
+	return providerType{}, false
+}
+
+// the backend for an API record
+func newProvider(apiRecord *models.Record) (Provider, error) {
+	providerType, ok := findProviderType(apiRecord.GetString("type"))
+	if !ok {
+		return nil, fmt.Errorf("API %s has unknown type %q", apiRecord.GetString("name"), apiRecord.GetString("type"))
+	}
+	return providerType.New(apiRecord), nil
+}
+
+// choices for the type select of the API editor
+func apiTypeOptions() []templates.ApiTypeParams {
+	var options []templates.ApiTypeParams
+	for _, providerType := range providerTypes {
+		options = append(options, templates.ApiTypeParams{Type: providerType.Type, Name: providerType.Name})
+	}
+	return options
+}
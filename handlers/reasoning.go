@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// thinking sent in a delta field of its own, reasoning_content (DeepSeek, vLLM) or reasoning (OpenRouter)
+// go-openai drops both, so they are read from the event stream by the event they came with
+type streamReasoning struct {
+	mu     sync.Mutex
+	events int
+	chunks map[int]string
+}
+
+func (r *streamReasoning) add(data []byte) {
+	// go-openai returns a chunk for every event except these
+	if bytes.Equal(data, []byte("[DONE]")) || bytes.HasPrefix(data, []byte(`{"error":`)) {
+		return
+	}
+
+	var chunk struct {
+		Choices []struct {
+			Delta struct {
+				ReasoningContent string `json:"reasoning_content"`
+				Reasoning        string `json:"reasoning"`
+			} `json:"delta"`
+		} `json:"choices"`
+	}
+	json.Unmarshal(data, &chunk)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(chunk.Choices) > 0 {
+		delta := chunk.Choices[0].Delta
+		if text := delta.ReasoningContent + delta.Reasoning; text != "" {
+			if r.chunks == nil {
+				r.chunks = make(map[int]string)
+			}
+			r.chunks[r.events] = text
+		}
+	}
+	r.events++
+}
+
+// reasoning of the nth chunk of the stream, the event has always been read by the time go-openai returns it
+func (r *streamReasoning) take(n int) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	text := r.chunks[n]
+	delete(r.chunks, n)
+	return text
+}
+
+type streamReasoningTransport struct {
+	base      http.RoundTripper
+	reasoning *streamReasoning
+}
+
+func (t *streamReasoningTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	response, err := t.base.RoundTrip(req)
+	if err != nil || response.StatusCode != http.StatusOK {
+		return response, err
+	}
+	response.Body = &dataLineReader{ReadCloser: response.Body, onData: t.reasoning.add}
+	return response, nil
+}
+
+const (
+	thinkOpenTag  = "<think>"
+	thinkCloseTag = "</think>"
+)
+
+// splits <think> blocks out of streamed content, tags can be cut across chunks
+// a block only opens before any text, so a model writing about the tags isn't cut short
+type thinkParser struct {
+	thinking bool
+	started  bool
+	// possible start of a tag or leading whitespace, held back until the next chunk
+	pending string
+}
+
+// leading whitespace, and the blank lines between the thinking and the answer, are dropped
+const thinkSpace = " \t\r\n"
+
+func (p *thinkParser) feed(chunk string) (text string, reasoning string) {
+	buffer := p.pending + chunk
+	p.pending = ""
+
+	if p.thinking {
+		i := strings.Index(buffer, thinkCloseTag)
+		if i < 0 {
+			// hold back the end of the chunk if the tag could continue in the next one
+			held := 0
+			for n := min(len(thinkCloseTag)-1, len(buffer)); n > 0; n-- {
+				if strings.HasSuffix(buffer, thinkCloseTag[:n]) {
+					held = n
+					break
+				}
+			}
+			p.pending = buffer[len(buffer)-held:]
+			return "", buffer[:len(buffer)-held]
+		}
+		reasoning = buffer[:i]
+		buffer = buffer[i+len(thinkCloseTag):]
+		p.thinking = false
+	}
+
+	if p.started {
+		return buffer, reasoning
+	}
+	trimmed := strings.TrimLeft(buffer, thinkSpace)
+	if strings.HasPrefix(trimmed, thinkOpenTag) {
+		p.thinking = true
+		text, more := p.feed(trimmed[len(thinkOpenTag):])
+		return text, reasoning + more
+	}
+	if strings.HasPrefix(thinkOpenTag, trimmed) {
+		p.pending = trimmed
+		return "", reasoning
+	}
+	p.started = true
+	return trimmed, reasoning
+}
+
+// whatever was held back once the stream ends
+func (p *thinkParser) flush() (text string, reasoning string) {
+	pending := p.pending
+	p.pending = ""
+	if p.thinking {
+		return "", pending
+	}
+	// the start of an opening tag that never finished is text after all
+	return pending, ""
+}
+
+// chat templates that start the model's turn inside a <think> block leave only the closing tag in the response
+// set on the API for chat endpoints, seen in the prompt for completion formats
+func opensThinkBlock(apiRecord *models.Record) bool {
+	if apiRecord.GetBool("template_opens_think") {
+		return true
+	}
+	providerType, ok := findProviderType(apiRecord.GetString("type"))
+	if !ok || providerType.Type != "openai" || apiRecord.GetString("prompt_format") == "" {
+		return false
+	}
+	prompt, _, err := renderPrompt(apiRecord, nil)
+	return err == nil && strings.HasSuffix(strings.TrimRight(prompt, thinkSpace), thinkOpenTag)
+}
+
+// thinking of a whole response, including a closing tag with no opening one when the
+// template opened the block, otherwise a closing tag only counts at the start of the response
+// so an answer that mentions the tag is left alone
+func splitReasoning(content string, opensThink bool) (text string, reasoning string) {
+	if before, after, ok := strings.Cut(content, thinkCloseTag); ok && !strings.Contains(before, thinkOpenTag) &&
+		(opensThink || strings.TrimSpace(before) == "") {
+		return strings.TrimLeft(after, thinkSpace), strings.TrimSpace(before)
+	}
+
+	var parser thinkParser
+	text, reasoning = parser.feed(content)
+	restText, restReasoning := parser.flush()
+	return text + restText, reasoning + restReasoning
+}
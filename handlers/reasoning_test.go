@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+)
+
+func TestSplitReasoning(t *testing.T) {
+	tests := []struct {
+		content       string
+		opensThink    bool
+		wantText      string
+		wantReasoning string
+	}{
+		{"<think>hmm</think>\n\nanswer", false, "answer", "hmm"},
+		{"hmm</think>\n\nanswer", true, "answer", "hmm"},
+		{"\n</think>\n\nanswer", false, "answer", ""},
+		// an answer that mentions the tag is left alone unless the template opened the block
+		{"Reasoning models close their thinking with </think> before answering.", false, "Reasoning models close their thinking with </think> before answering.", ""},
+		{"plain answer", true, "plain answer", ""},
+	}
+	for _, test := range tests {
+		text, reasoning := splitReasoning(test.content, test.opensThink)
+		if text != test.wantText || reasoning != test.wantReasoning {
+			t.Errorf("splitReasoning(%q, %v) = %q, %q, want %q, %q", test.content, test.opensThink, text, reasoning, test.wantText, test.wantReasoning)
+		}
+	}
+}
+
+func TestOpensThinkBlock(t *testing.T) {
+	apiRecord := models.NewRecord(&models.Collection{Name: "apis"})
+	if opensThinkBlock(apiRecord) {
+		t.Error("a chat API should not open the block unless it is set")
+	}
+
+	apiRecord.Set("template_opens_think", true)
+	if !opensThinkBlock(apiRecord) {
+		t.Error("the API setting should open the block")
+	}
+
+	apiRecord.Set("template_opens_think", false)
+	apiRecord.Set("prompt_format", "custom")
+	apiRecord.Set("prompt_template", "{{range .Messages}}{{.Content}}\n{{end}}assistant:\n<think>\n")
+	if !opensThinkBlock(apiRecord) {
+		t.Error("a completion template ending in <think> should open the block")
+	}
+
+	apiRecord.Set("prompt_format", "chatml")
+	if opensThinkBlock(apiRecord) {
+		t.Error("ChatML doesn't open the block")
+	}
+}
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// retries of a failed request before moving on to the next fallback
+const maxRetries = 3
+
+const (
+	retryBaseDelay = time.Second
+	retryMaxDelay  = 30 * time.Second
+	// an API asking to wait longer than this is given up on right away
+	retryMaxWait = time.Minute
+)
+
+// HTTP status of a failed request, 0 when it didn't get a response
+func errorStatus(err error) int {
+	var apiErr *openai.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.HTTPStatusCode
+	}
+	var requestErr *openai.RequestError
+	if errors.As(err, &requestErr) {
+		return requestErr.HTTPStatusCode
+	}
+	return 0
+}
+
+// rate limits, server errors and dropped connections usually pass, other errors won't
+func retryableError(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	if status := errorStatus(err); status != 0 {
+		return status == http.StatusTooManyRequests || status >= 500
+	}
+
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// short reason shown while waiting to retry
+func retryReason(err error) string {
+	status := errorStatus(err)
+	switch {
+	case status == http.StatusTooManyRequests:
+		return "rate limited"
+	case status >= 500:
+		return "server error " + strconv.Itoa(status)
+	}
+	return "connection failed"
+}
+
+// wait before retry number retry+1, what the API asked for if it said
+// false when the API asked for a longer wait than is worth sitting through
+func retryDelay(retry int, retryAfter time.Duration) (time.Duration, bool) {
+	if retryAfter > 0 {
+		return retryAfter, retryAfter <= retryMaxWait
+	}
+
+	delay := min(retryBaseDelay<<retry, retryMaxDelay)
+	// jitter keeps parallel compare answers from retrying in lockstep
+	return delay + rand.N(delay/4+1), true
+}
+
+// seconds or an HTTP date, 0 when missing or unreadable
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0)
+	}
+	return 0
+}
+
+// Retry-After of the last failed response of a request, go-openai doesn't pass headers on
+type retryHint struct {
+	mu    sync.Mutex
+	after time.Duration
+}
+
+func (h *retryHint) set(after time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.after = after
+}
+
+func (h *retryHint) get() time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.after
+}
+
+type retryHintKey struct{}
+
+// requests made with the returned context report their Retry-After to the hint
+func withRetryHint(ctx context.Context) (context.Context, *retryHint) {
+	hint := &retryHint{}
+	return context.WithValue(ctx, retryHintKey{}, hint), hint
+}
+
+type retryAfterTransport struct {
+	base http.RoundTripper
+}
+
+func (t *retryAfterTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	response, err := t.base.RoundTrip(req)
+	if err != nil || response.StatusCode < 400 {
+		return response, err
+	}
+
+	if hint, ok := req.Context().Value(retryHintKey{}).(*retryHint); ok {
+		hint.set(parseRetryAfter(response.Header.Get("Retry-After")))
+	}
+	return response, nil
+}
+
+// wait out a retry delay, false if the generation was stopped meanwhile
+func sleepContext(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+func retryStatusMessage(err error, delay time.Duration, retry int) string {
+	return fmt.Sprintf("%s, retry %d of %d in %s", retryReason(err), retry, maxRetries, delay.Round(time.Second))
+}
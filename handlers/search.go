@@ -2,8 +2,13 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"slices"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/erikmillergalow/htmx-llmchat/templates"
 
@@ -24,6 +29,7 @@ func OpenSearch(c echo.Context, app *pocketbase.PocketBase) error {
 	app.Dao().DB().
 		Select("*").
 		From("chat").
+		Where(dbx.NewExp("draft = FALSE")).
 		OrderBy("created DESC").
 		All(&allChatParams)
 
@@ -45,53 +51,64 @@ func OpenSearch(c echo.Context, app *pocketbase.PocketBase) error {
 }
 
 func Search(data map[string]any, c echo.Context, app *pocketbase.PocketBase) error {
-	searchValue := data["search-input"].(string)
-	tagFilter := data["tag"].(string)
-	modelFilter := data["model"].(string)
-	usefulFilter := data["useful"].(string)
-
-	var relevantMessages []templates.LoadedMessageParams
-	query := app.Dao().DB().
-		Select("*").
-		From("chat")
-
-	if usefulFilter == "useful" {
-		query = query.Where(dbx.NewExp("useful = true"))
-	} else if usefulFilter == "not-useful" {
-		query = query.Where(dbx.NewExp("thread_id NOT IN (SELECT DISTINCT thread_id FROM chat WHERE useful = true)"))
+	query := parseSearch(FormValue(data, "search-input"))
+	if query == nil {
+		return renderSearchNote("Enter something to search for", c)
 	}
 
-	if searchValue != "" {
-		query = query.AndWhere(dbx.Like("message", searchValue))
+	if FormValue(data, "mode") == "meaning" {
+		return SearchByMeaning(query, c, app)
 	}
+	if query.hasText() {
+		return SearchText(query, c, app)
+	}
+	return SearchThreads(query, c, app)
+}
 
-	query.All(&relevantMessages)
-
-	var relevantMessageIds []string
-	for _, message := range relevantMessages {
-		if modelFilter == "any" || modelFilter == message.Model {
-			relevantMessageIds = append(relevantMessageIds, message.ThreadId)
-		}
+// without search text the filters list whole threads
+func SearchThreads(query *searchNode, c echo.Context, app *pocketbase.PocketBase) error {
+	compiler := &searchCompiler{params: dbx.Params{}}
+	condition, err := compiler.condition(query)
+	if err != nil {
+		return renderSearchNote(err.Error(), c)
 	}
 
-	var relevantThreadRecords, err = app.Dao().FindRecordsByIds("chat_meta", relevantMessageIds)
+	// threads without messages only match filters on the thread itself
+	var threadIds []string
+	err = app.Dao().DB().
+		NewQuery(`SELECT DISTINCT m.id FROM chat_meta m
+			LEFT JOIN chat c ON c.thread_id = m.id AND c.draft = FALSE AND c.id IN (` + shownMessagesQuery + `)
+			WHERE ` + condition).
+		Bind(compiler.params).
+		Column(&threadIds)
 	if err != nil {
+		fmt.Printf("Failed to search threads: %v\n", err)
 		return c.String(http.StatusInternalServerError, "failed to fetch threads relevant to search")
 	}
 
+	relevantThreadRecords, err := app.Dao().FindRecordsByIds("chat_meta", threadIds)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to fetch threads relevant to search")
+	}
+	sort.Slice(relevantThreadRecords, func(i, j int) bool {
+		return relevantThreadRecords[i].GetDateTime("last_message_timestamp").Time().After(relevantThreadRecords[j].GetDateTime("last_message_timestamp").Time())
+	})
 
 	// convert slice of records to struct templ expects, need to check for better ways to handle this...
 	var relevantThreads []templates.ThreadListEntryParams
 	for _, record := range relevantThreadRecords {
-		if tagFilter == "any" || slices.Contains(record.GetStringSlice("tags"), tagFilter) {
-			thread := templates.ThreadListEntryParams{
-				Id:                   record.GetString("id"),
-				Title:                record.GetString("thread_title"),
-				LastMessageTimestamp: record.GetDateTime("last_message_timestamp"),
-				Created:              record.GetDateTime("created"),
-			}
-			relevantThreads = append(relevantThreads, thread)
+		thread := templates.ThreadListEntryParams{
+			Id:                   record.GetString("id"),
+			Title:                record.GetString("thread_title"),
+			LastMessageTimestamp: record.GetDateTime("last_message_timestamp"),
+			Created:              record.GetDateTime("created"),
+			Generating:           generationJobs.generating(record.Id),
 		}
+		relevantThreads = append(relevantThreads, thread)
+	}
+
+	if len(relevantThreads) == 0 {
+		return renderSearchNote("No threads found", c)
 	}
 
 	var allTags [][]templates.TagParams
@@ -112,3 +129,209 @@ func Search(data map[string]any, c echo.Context, app *pocketbase.PocketBase) err
 
 	return nil
 }
+
+// how many matches a text search shows
+const textSearchResults = 50
+
+type textMatch struct {
+	MessageId   string `db:"message_id"`
+	ThreadId    string `db:"thread_id"`
+	ThreadTitle string `db:"thread_title"`
+	Sender      string `db:"sender"`
+	Snippet     string `db:"snippet"`
+}
+
+// messages and thread titles matching the search, best BM25 match first
+func SearchText(query *searchNode, c echo.Context, app *pocketbase.PocketBase) error {
+	compiler := &searchCompiler{params: dbx.Params{}}
+
+	// title rows have no chat row to filter on, so they go by the messages of their thread
+	// messages of branches that aren't shown can't be jumped to
+	conditions := []string{"(s.message_id = '' OR (c.id IS NOT NULL AND c.draft = FALSE AND c.id IN (" + shownMessagesQuery + ")))"}
+	match, rest := splitSearch(query)
+	if rest != nil {
+		condition, err := compiler.condition(rest)
+		if err != nil {
+			return renderSearchNote(err.Error(), c)
+		}
+		conditions = append(conditions, condition)
+	}
+
+	// text that is only excluded or grouped with filters can't be ranked, newest first then
+	snippet := "CASE WHEN length(s.body) > 200 THEN substr(s.body, 1, 200) || '…' ELSE s.body END"
+	order := "COALESCE(c.created, m.created) DESC"
+	if match != "" {
+		conditions = append(conditions, "message_search MATCH "+compiler.param(match))
+		snippet = "snippet(message_search, 0, char(2), char(3), '…', 16)"
+		order = "bm25(message_search)"
+	}
+
+	var matches []textMatch
+	err := app.Dao().DB().
+		NewQuery(`SELECT s.message_id, s.thread_id, m.thread_title, COALESCE(c.sender, '') AS sender,
+			` + snippet + ` AS snippet
+			FROM message_search s
+			INNER JOIN chat_meta m ON m.id = s.thread_id
+			LEFT JOIN chat c ON c.id = s.message_id
+			WHERE ` + strings.Join(conditions, " AND ") + `
+			ORDER BY ` + order + `
+			LIMIT ` + strconv.Itoa(textSearchResults)).
+		Bind(compiler.params).
+		All(&matches)
+	if err != nil {
+		fmt.Printf("Failed to search messages: %v\n", err)
+		return c.String(http.StatusInternalServerError, "failed to search messages")
+	}
+
+	if len(matches) == 0 {
+		return renderSearchNote("No messages found", c)
+	}
+
+	var results []templates.SearchResultParams
+	for _, match := range matches {
+		detail := match.Sender
+		if match.MessageId == "" {
+			detail = "title"
+		}
+		results = append(results, templates.SearchResultParams{
+			ThreadId:    match.ThreadId,
+			MessageId:   match.MessageId,
+			ThreadTitle: match.ThreadTitle,
+			Detail:      detail,
+			Snippet:     highlightSnippet(match.Snippet),
+		})
+	}
+
+	c.Response().Writer.WriteHeader(200)
+	searchResults := templates.SearchResults(results)
+	err = searchResults.Render(context.Background(), c.Response().Writer)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to render search results")
+	}
+
+	return nil
+}
+
+// how many messages a search by meaning shows
+const meaningSearchResults = 20
+
+type embeddedPassage struct {
+	MessageId   string `db:"message_id"`
+	ThreadId    string `db:"thread_id"`
+	Text        string `db:"text"`
+	Vector      string `db:"vector"`
+	Model       string `db:"chat_model"`
+	Sender      string `db:"sender"`
+	ThreadTitle string `db:"thread_title"`
+}
+
+// messages ranked by how close their best passage is to the query
+// the words of the query are embedded together, its filters narrow the messages compared
+func SearchByMeaning(query *searchNode, c echo.Context, app *pocketbase.PocketBase) error {
+	searchValue := strings.Join(query.positiveText(), " ")
+	if searchValue == "" {
+		return renderSearchNote("Enter something to search for", c)
+	}
+
+	compiler := &searchCompiler{params: dbx.Params{}}
+	var filter string
+	if filters := query.withoutText(); filters != nil {
+		condition, err := compiler.condition(filters)
+		if err != nil {
+			return renderSearchNote(err.Error(), c)
+		}
+		filter = condition
+	}
+
+	apiRecord, model, key, err := embeddingSettings(app)
+	if errors.Is(err, errEmbeddingsNotConfigured) {
+		return renderSearchNote("Pick an embedding API and model in the config menu to search by meaning", c)
+	}
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to load embedding settings")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), embeddingRequestTimeout)
+	defer cancel()
+	vectors, err := embedTexts(ctx, apiRecord, model, []string{searchValue})
+	if err != nil {
+		fmt.Printf("Failed to embed search query: %v\n", err)
+		return renderSearchNote("Unable to reach the embedding API", c)
+	}
+	queryVector := vectors[0]
+
+	// messages of branches that aren't shown can't be jumped to
+	passagesQuery := app.Dao().DB().
+		Select("e.message_id", "e.thread_id", "e.text", "e.vector", "c.model AS chat_model", "c.sender", "m.thread_title").
+		From("message_embeddings e").
+		InnerJoin("chat c", dbx.NewExp("c.id = e.message_id")).
+		InnerJoin("chat_meta m", dbx.NewExp("m.id = c.thread_id")).
+		Where(dbx.HashExp{"e.model": key}).
+		AndWhere(dbx.NewExp("c.draft = FALSE")).
+		AndWhere(dbx.NewExp("c.id IN (" + shownMessagesQuery + ")"))
+	if filter != "" {
+		passagesQuery = passagesQuery.AndWhere(dbx.NewExp(filter, compiler.params))
+	}
+
+	var passages []embeddedPassage
+	if err := passagesQuery.All(&passages); err != nil {
+		fmt.Printf("Failed to load message embeddings: %v\n", err)
+		return c.String(http.StatusInternalServerError, "failed to load message embeddings")
+	}
+
+	// the best passage of each message
+	best := map[string]templates.SearchResultParams{}
+	for _, passage := range passages {
+		vector, err := decodeVector(passage.Vector)
+		if err != nil || len(vector) != len(queryVector) {
+			continue
+		}
+		score := dotProduct(queryVector, vector)
+		if result, ok := best[passage.MessageId]; ok && result.Score >= score {
+			continue
+		}
+		best[passage.MessageId] = templates.SearchResultParams{
+			ThreadId:    passage.ThreadId,
+			MessageId:   passage.MessageId,
+			ThreadTitle: passage.ThreadTitle,
+			Detail:      passage.Sender + " · " + strconv.FormatFloat(score, 'f', 2, 64),
+			Passage:     passage.Text,
+			Score:       score,
+		}
+	}
+
+	var results []templates.SearchResultParams
+	for _, result := range best {
+		results = append(results, result)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	if len(results) > meaningSearchResults {
+		results = results[:meaningSearchResults]
+	}
+
+	if len(results) == 0 {
+		return renderSearchNote("No messages found, new messages take a moment to be indexed", c)
+	}
+
+	c.Response().Writer.WriteHeader(200)
+	searchResults := templates.SearchResults(results)
+	err = searchResults.Render(context.Background(), c.Response().Writer)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to render search results")
+	}
+
+	return nil
+}
+
+func renderSearchNote(note string, c echo.Context) error {
+	c.Response().Writer.WriteHeader(200)
+	searchNote := templates.SearchNote(note)
+	err := searchNote.Render(context.Background(), c.Response().Writer)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to render search note")
+	}
+
+	return nil
+}
@@ -0,0 +1,430 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/pocketbase/dbx"
+)
+
+// a parsed search such as `tag:golang model:llama3* -sender:model ("exact phrase" OR error)`
+// words and phrases are matched against message text and thread titles, fields filter by
+// what the message or thread is, a leading - or NOT excludes, OR and parentheses group
+type searchNode struct {
+	kind     searchNodeKind
+	children []*searchNode
+	// field of a filter, empty for text
+	field string
+	value string
+	// a word ending in *, matching as a prefix
+	prefix bool
+}
+
+type searchNodeKind int
+
+const (
+	searchAnd searchNodeKind = iota
+	searchOr
+	searchNot
+	searchTerm
+)
+
+// fields filters can use, anything else before a colon is searched as text
+var searchFields = []string{"tag", "model", "sender", "useful", "after", "before"}
+
+type searchToken struct {
+	text string
+	// quoted, so OR, NOT and fields are taken literally
+	quoted bool
+}
+
+// split a query into words, quoted phrases, parentheses and the - of exclusions
+// a field's value can be quoted too, as in tag:"machine learning"
+func tokenizeSearch(query string) []searchToken {
+	var tokens []searchToken
+	runes := []rune(query)
+	for i := 0; i < len(runes); {
+		char := runes[i]
+		switch {
+		case unicode.IsSpace(char):
+			i++
+		case char == '(' || char == ')':
+			tokens = append(tokens, searchToken{text: string(char)})
+			i++
+		case char == '-' && i+1 < len(runes) && !unicode.IsSpace(runes[i+1]) && (i == 0 || unicode.IsSpace(runes[i-1]) || runes[i-1] == '('):
+			tokens = append(tokens, searchToken{text: "NOT"})
+			i++
+		case char == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			tokens = append(tokens, searchToken{text: string(runes[i+1 : end]), quoted: true})
+			i = end + 1
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && runes[end] != '(' && runes[end] != ')' && runes[end] != '"' {
+				end++
+			}
+			word := string(runes[i:end])
+			// the quoted value of a field
+			if strings.HasSuffix(word, ":") && end < len(runes) && runes[end] == '"' {
+				valueEnd := end + 1
+				for valueEnd < len(runes) && runes[valueEnd] != '"' {
+					valueEnd++
+				}
+				word += string(runes[end+1 : valueEnd])
+				end = valueEnd + 1
+			}
+			tokens = append(tokens, searchToken{text: word})
+			i = end
+		}
+	}
+	return tokens
+}
+
+type searchParser struct {
+	tokens []searchToken
+	pos    int
+}
+
+// parse a query, nil if it has nothing to search for
+// unbalanced parentheses are forgiven, a missing ) closes at the end and a stray one is skipped
+func parseSearch(query string) *searchNode {
+	parser := &searchParser{tokens: tokenizeSearch(query)}
+	var parts []*searchNode
+	for parser.pos < len(parser.tokens) {
+		if node := parser.parseOr(); node != nil {
+			parts = append(parts, node)
+		}
+		if parser.peek(")") {
+			parser.pos++
+		}
+	}
+	return combineSearch(searchAnd, parts)
+}
+
+func (p *searchParser) peek(text string) bool {
+	return p.pos < len(p.tokens) && !p.tokens[p.pos].quoted && p.tokens[p.pos].text == text
+}
+
+func (p *searchParser) parseOr() *searchNode {
+	alternatives := []*searchNode{p.parseAnd()}
+	for p.peek("OR") {
+		p.pos++
+		alternatives = append(alternatives, p.parseAnd())
+	}
+	return combineSearch(searchOr, alternatives)
+}
+
+func (p *searchParser) parseAnd() *searchNode {
+	var parts []*searchNode
+	for p.pos < len(p.tokens) && !p.peek(")") && !p.peek("OR") {
+		if p.peek("AND") {
+			p.pos++
+			continue
+		}
+		if node := p.parseUnary(); node != nil {
+			parts = append(parts, node)
+		}
+	}
+	return combineSearch(searchAnd, parts)
+}
+
+func (p *searchParser) parseUnary() *searchNode {
+	// a NOT with nothing after it is dropped
+	if p.pos >= len(p.tokens) || p.peek(")") || p.peek("OR") {
+		return nil
+	}
+	if p.peek("NOT") {
+		p.pos++
+		if child := p.parseUnary(); child != nil {
+			return &searchNode{kind: searchNot, children: []*searchNode{child}}
+		}
+		return nil
+	}
+	if p.peek("(") {
+		p.pos++
+		node := p.parseOr()
+		if p.peek(")") {
+			p.pos++
+		}
+		return node
+	}
+
+	token := p.tokens[p.pos]
+	p.pos++
+	if token.quoted {
+		if strings.TrimSpace(token.text) == "" {
+			return nil
+		}
+		return &searchNode{kind: searchTerm, value: token.text}
+	}
+
+	if field, value, ok := strings.Cut(token.text, ":"); ok && value != "" {
+		field = strings.ToLower(field)
+		for _, searchField := range searchFields {
+			if field == searchField {
+				return &searchNode{kind: searchTerm, field: field, value: value}
+			}
+		}
+	}
+	word := strings.TrimRight(token.text, "*")
+	if word == "" {
+		return nil
+	}
+	return &searchNode{kind: searchTerm, value: word, prefix: word != token.text}
+}
+
+// a group of nodes, or the only one
+func combineSearch(kind searchNodeKind, nodes []*searchNode) *searchNode {
+	var kept []*searchNode
+	for _, node := range nodes {
+		if node != nil {
+			kept = append(kept, node)
+		}
+	}
+	switch len(kept) {
+	case 0:
+		return nil
+	case 1:
+		return kept[0]
+	}
+	return &searchNode{kind: kind, children: kept}
+}
+
+func (n *searchNode) isText() bool {
+	return n.kind == searchTerm && n.field == ""
+}
+
+// whether the query searches text anywhere, a query of only filters lists threads instead
+func (n *searchNode) hasText() bool {
+	if n == nil {
+		return false
+	}
+	if n.kind == searchTerm {
+		return n.isText()
+	}
+	for _, child := range n.children {
+		if child.hasText() {
+			return true
+		}
+	}
+	return false
+}
+
+// the words and phrases a search by meaning embeds, excluded ones left out
+func (n *searchNode) positiveText() []string {
+	if n == nil || n.kind == searchNot {
+		return nil
+	}
+	if n.kind == searchTerm {
+		if n.isText() {
+			return []string{n.value}
+		}
+		return nil
+	}
+	var text []string
+	for _, child := range n.children {
+		text = append(text, child.positiveText()...)
+	}
+	return text
+}
+
+// the query without its text, for searching by meaning
+func (n *searchNode) withoutText() *searchNode {
+	if n == nil || n.isText() {
+		return nil
+	}
+	if n.kind == searchTerm {
+		return n
+	}
+	var children []*searchNode
+	for _, child := range n.children {
+		children = append(children, child.withoutText())
+	}
+	if n.kind == searchNot {
+		if children[0] == nil {
+			return nil
+		}
+		return &searchNode{kind: searchNot, children: children}
+	}
+	return combineSearch(n.kind, children)
+}
+
+// the query as an FTS5 expression, false if it filters by a field or only excludes
+// FTS5 has no NOT on its own, exclusions need something to be taken out of
+func (n *searchNode) ftsExpression() (string, bool) {
+	switch n.kind {
+	case searchTerm:
+		if !n.isText() {
+			return "", false
+		}
+		expression := `"` + strings.ReplaceAll(n.value, `"`, `""`) + `"`
+		if n.prefix {
+			expression += "*"
+		}
+		return expression, true
+	case searchOr:
+		var alternatives []string
+		for _, child := range n.children {
+			expression, ok := child.ftsExpression()
+			if !ok {
+				return "", false
+			}
+			alternatives = append(alternatives, expression)
+		}
+		return "(" + strings.Join(alternatives, " OR ") + ")", true
+	case searchAnd:
+		var included, excluded []string
+		for _, child := range n.children {
+			if child.kind == searchNot {
+				expression, ok := child.children[0].ftsExpression()
+				if !ok {
+					return "", false
+				}
+				excluded = append(excluded, expression)
+				continue
+			}
+			expression, ok := child.ftsExpression()
+			if !ok {
+				return "", false
+			}
+			included = append(included, expression)
+		}
+		if len(included) == 0 {
+			return "", false
+		}
+		expression := "(" + strings.Join(included, " AND ") + ")"
+		for _, exclusion := range excluded {
+			expression += " NOT " + exclusion
+		}
+		return "(" + expression + ")", true
+	}
+	return "", false
+}
+
+// split a query into an FTS5 expression, matched and ranked directly, and the rest as SQL
+// the text at the top of the query goes into the expression, text grouped with filters
+// is matched in a subquery
+func splitSearch(n *searchNode) (string, *searchNode) {
+	if n == nil {
+		return "", nil
+	}
+	if expression, ok := n.ftsExpression(); ok {
+		return expression, nil
+	}
+	if n.kind != searchAnd {
+		return "", n
+	}
+
+	var text, rest []*searchNode
+	for _, child := range n.children {
+		target := child
+		if child.kind == searchNot {
+			target = child.children[0]
+		}
+		if _, ok := target.ftsExpression(); ok {
+			text = append(text, child)
+		} else {
+			rest = append(rest, child)
+		}
+	}
+	if textNode := combineSearch(searchAnd, text); textNode != nil {
+		if expression, ok := textNode.ftsExpression(); ok {
+			return expression, combineSearch(searchAnd, rest)
+		}
+	}
+	return "", n
+}
+
+// builds a SQL condition from a query, every value is bound as a parameter
+// expects the FTS table as s, the thread as m and the message as c, which is
+// NULL for thread titles
+type searchCompiler struct {
+	params dbx.Params
+}
+
+func (compiler *searchCompiler) param(value any) string {
+	name := "search" + strconv.Itoa(len(compiler.params))
+	compiler.params[name] = value
+	return "{:" + name + "}"
+}
+
+func (compiler *searchCompiler) condition(n *searchNode) (string, error) {
+	switch n.kind {
+	case searchAnd, searchOr:
+		operator := " AND "
+		if n.kind == searchOr {
+			operator = " OR "
+		}
+		var conditions []string
+		for _, child := range n.children {
+			condition, err := compiler.condition(child)
+			if err != nil {
+				return "", err
+			}
+			conditions = append(conditions, condition)
+		}
+		return "(" + strings.Join(conditions, operator) + ")", nil
+	case searchNot:
+		condition, err := compiler.condition(n.children[0])
+		if err != nil {
+			return "", err
+		}
+		return "NOT " + condition, nil
+	}
+
+	if n.isText() {
+		expression, _ := n.ftsExpression()
+		return "s.rowid IN (SELECT rowid FROM message_search WHERE message_search MATCH " + compiler.param(expression) + ")", nil
+	}
+
+	switch n.field {
+	case "tag":
+		return "(json_valid(m.tags) AND EXISTS (SELECT 1 FROM json_each(m.tags) j INNER JOIN tags t ON t.id = j.value WHERE t.value LIKE " +
+			compiler.param(likePattern(n.value)) + " ESCAPE '\\'))", nil
+	case "model":
+		return messageCondition("%s.model LIKE " + compiler.param(likePattern(n.value)) + " ESCAPE '\\'"), nil
+	case "sender":
+		sender := strings.ToLower(n.value)
+		if sender == "user" || sender == "you" {
+			sender = "human"
+		}
+		return messageCondition("%s.sender = " + compiler.param(sender)), nil
+	case "useful":
+		switch strings.ToLower(n.value) {
+		case "yes", "true":
+			return messageCondition("%s.useful = TRUE"), nil
+		case "no", "false":
+			return messageCondition("%s.useful = FALSE"), nil
+		}
+		return "", fmt.Errorf("useful: takes yes or no, not %q", n.value)
+	case "after", "before":
+		date, err := time.Parse(time.DateOnly, n.value)
+		if err != nil {
+			return "", fmt.Errorf("%s: takes a date like 2024-05-01, not %q", n.field, n.value)
+		}
+		// after includes the day itself, before doesn't
+		if n.field == "after" {
+			return messageCondition("%s.created >= " + compiler.param(date.Format(time.DateTime))), nil
+		}
+		return messageCondition("%s.created < " + compiler.param(date.Format(time.DateTime))), nil
+	}
+	return "", fmt.Errorf("unknown search field %q", n.field)
+}
+
+// a condition on the message, or for a thread title on any shown message of the thread
+func messageCondition(format string) string {
+	return "(CASE WHEN c.id IS NULL THEN EXISTS (SELECT 1 FROM chat x WHERE x.thread_id = m.id AND x.draft = FALSE AND x.id IN (" + shownMessagesQuery + ") AND " +
+		fmt.Sprintf(format, "x") + ") ELSE " + fmt.Sprintf(format, "c") + " END)"
+}
+
+// a value with * wildcards as a LIKE pattern, % and _ are taken literally
+func likePattern(value string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+	return strings.ReplaceAll(escaped, "*", "%")
+}
@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/dbx"
+)
+
+// a query tree in a compact form for comparing
+func formatSearch(n *searchNode) string {
+	if n == nil {
+		return "nil"
+	}
+	switch n.kind {
+	case searchTerm:
+		term := n.value
+		if n.field != "" {
+			term = n.field + ":" + term
+		}
+		if n.prefix {
+			term += "*"
+		}
+		return term
+	case searchNot:
+		return "NOT(" + formatSearch(n.children[0]) + ")"
+	}
+	var children []string
+	for _, child := range n.children {
+		children = append(children, formatSearch(child))
+	}
+	operator := "AND"
+	if n.kind == searchOr {
+		operator = "OR"
+	}
+	return operator + "(" + strings.Join(children, ", ") + ")"
+}
+
+func TestParseSearch(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"", "nil"},
+		{"hello", "hello"},
+		{"hello world", "AND(hello, world)"},
+		{"hello AND world", "AND(hello, world)"},
+		{"go* -rust", "AND(go*, NOT(rust))"},
+		{`"exact phrase" NOT "other phrase"`, "AND(exact phrase, NOT(other phrase))"},
+		{"(go OR rust) sender:model", "AND(OR(go, rust), sender:model)"},
+		{`tag:"machine learning" model:llama3*`, "AND(tag:machine learning, model:llama3*)"},
+		{"TAG:golang after:2024-05-01", "AND(tag:golang, after:2024-05-01)"},
+		{"foo:bar", "foo:bar"},
+		{"well-known", "well-known"},
+		{`"OR"`, "OR"},
+		// unbalanced parentheses are forgiven
+		{"(go OR rust", "OR(go, rust)"},
+		{"go) rust", "AND(go, rust)"},
+	}
+	for _, test := range tests {
+		if got := formatSearch(parseSearch(test.query)); got != test.want {
+			t.Errorf("parseSearch(%q) = %s, want %s", test.query, got, test.want)
+		}
+	}
+}
+
+// incomplete input is typed all the time and must not fail the request
+func TestParseSearchIncomplete(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"NOT", "nil"},
+		{"foo NOT", "foo"},
+		{"(NOT", "nil"},
+		{"foo -)", "foo"},
+		{"-)", "nil"},
+		{"NOT OR foo", "foo"},
+		{"foo OR", "foo"},
+		{"OR", "nil"},
+		{"()", "nil"},
+		{")(", "nil"},
+		{`"`, "nil"},
+		{`tag:"`, "tag:"},
+		{"-", "-"},
+		{"*", "nil"},
+	}
+	for _, test := range tests {
+		if got := formatSearch(parseSearch(test.query)); got != test.want {
+			t.Errorf("parseSearch(%q) = %s, want %s", test.query, got, test.want)
+		}
+	}
+}
+
+func TestSplitSearch(t *testing.T) {
+	tests := []struct {
+		query     string
+		wantMatch string
+		wantRest  string
+	}{
+		{"hello", `"hello"`, "nil"},
+		{"go* -rust", `(("go"*) NOT "rust")`, "nil"},
+		{`"c++ (templates)" OR go`, `("c++ (templates)" OR "go")`, "nil"},
+		{"(go OR rust) sender:model", `("go" OR "rust")`, "sender:model"},
+		{"leak tag:golang useful:yes", `"leak"`, "AND(tag:golang, useful:yes)"},
+		// text that can't stand on its own in FTS5 is matched in a subquery
+		{"go OR tag:golang", "", "OR(go, tag:golang)"},
+		{"-rust sender:human", "", "AND(NOT(rust), sender:human)"},
+		{"sender:human", "", "sender:human"},
+	}
+	for _, test := range tests {
+		match, rest := splitSearch(parseSearch(test.query))
+		if match != test.wantMatch || formatSearch(rest) != test.wantRest {
+			t.Errorf("splitSearch(%q) = %s, %s, want %s, %s", test.query, match, formatSearch(rest), test.wantMatch, test.wantRest)
+		}
+	}
+}
+
+func TestSearchCondition(t *testing.T) {
+	tests := []struct {
+		query      string
+		wantSQL    []string
+		wantParams dbx.Params
+	}{
+		{
+			query:      "tag:machine*",
+			wantSQL:    []string{"json_each(m.tags)", "t.value LIKE {:search0} ESCAPE"},
+			wantParams: dbx.Params{"search0": "machine%"},
+		},
+		{
+			query:      "model:gpt-4_o%",
+			wantSQL:    []string{"c.model LIKE {:search0} ESCAPE", "x.model LIKE {:search0} ESCAPE"},
+			wantParams: dbx.Params{"search0": `gpt-4\_o\%`},
+		},
+		{
+			query:      "sender:user useful:no",
+			wantSQL:    []string{"c.sender = {:search0}", "c.useful = FALSE"},
+			wantParams: dbx.Params{"search0": "human"},
+		},
+		{
+			query:      "after:2024-05-01 before:2024-06-01",
+			wantSQL:    []string{"c.created >= {:search0}", "c.created < {:search1}"},
+			wantParams: dbx.Params{"search0": "2024-05-01 00:00:00", "search1": "2024-06-01 00:00:00"},
+		},
+		{
+			query:      "go OR -sender:model",
+			wantSQL:    []string{"message_search MATCH {:search0}", " OR NOT ", "c.sender = {:search1}"},
+			wantParams: dbx.Params{"search0": `"go"`, "search1": "model"},
+		},
+	}
+	for _, test := range tests {
+		compiler := &searchCompiler{params: dbx.Params{}}
+		condition, err := compiler.condition(parseSearch(test.query))
+		if err != nil {
+			t.Errorf("condition(%q) failed: %v", test.query, err)
+			continue
+		}
+		for _, want := range test.wantSQL {
+			if !strings.Contains(condition, want) {
+				t.Errorf("condition(%q) = %s, missing %s", test.query, condition, want)
+			}
+		}
+		if len(compiler.params) != len(test.wantParams) {
+			t.Errorf("condition(%q) params = %v, want %v", test.query, compiler.params, test.wantParams)
+		}
+		for name, want := range test.wantParams {
+			if compiler.params[name] != want {
+				t.Errorf("condition(%q) param %s = %v, want %v", test.query, name, compiler.params[name], want)
+			}
+		}
+	}
+}
+
+func TestSearchConditionInvalid(t *testing.T) {
+	for _, query := range []string{"after:2024-13-01", "before:yesterday", "useful:maybe"} {
+		compiler := &searchCompiler{params: dbx.Params{}}
+		if _, err := compiler.condition(parseSearch(query)); err == nil {
+			t.Errorf("condition(%q) should fail", query)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 
@@ -27,7 +28,8 @@ func collectAllThreads(sortBy string, app *pocketbase.PocketBase) ([]templates.T
 	var tags [][]templates.TagParams
 
 	// load thread tags
-	for _, thread := range threads {
+	for i, thread := range threads {
+		threads[i].Generating = generationJobs.generating(thread.Id)
 		threadTags, err := LoadThreadTags(thread.Id, app)
 		if err != nil {
 			return nil, nil, err
@@ -69,21 +71,64 @@ func GetThreadList(sortMethod string, c echo.Context, app *pocketbase.PocketBase
 }
 
 func GetThread(id string, c echo.Context, app *pocketbase.PocketBase) error {
-	var messages []templates.LoadedMessageParams
-	app.Dao().DB().
-		Select("*").
-		From("chat").
-		Where(dbx.NewExp("thread_id = {:id}", dbx.Params{"id": id})).
-		All(&messages)
+	return renderThread(id, "", c, app)
+}
+
+// load a thread and scroll to one of its messages, for search results
+func JumpToMessage(id string, messageId string, c echo.Context, app *pocketbase.PocketBase) error {
+	trigger, _ := json.Marshal(map[string]string{"jump-to-message": messageId})
+	c.Response().Header().Set("HX-Trigger-After-Settle", string(trigger))
+	return renderThread(id, "", c, app)
+}
+
+// render the active path of a thread into the chat window
+// respondTo is the id of a human message that still needs a model response, if any
+func renderThread(id string, respondTo string, c echo.Context, app *pocketbase.PocketBase) error {
+	// active path of the thread, marked with the messages the next request leaves out
+	contextUsage, messages, err := previewThreadContext(id, app)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to fetch thread messages")
+	}
+
+	// responses still streaming show what has been generated so far
+	for i := range messages {
+		withStreamSnapshot(&messages[i])
+		for j := range messages[i].CompareAnswers {
+			withStreamSnapshot(&messages[i].CompareAnswers[j])
+		}
+	}
 
 	threadRecord, err := app.Dao().FindRecordById("chat_meta", id)
 	if err != nil {
 		return c.String(http.StatusInternalServerError, "failed to fetch thread record for loading thread title")
 	}
 
-	c.Response().Header().Set("HX-Trigger-After-Settle", "format-thread-markdown")
+	// unset thread parameters fall back to the selected API
+	var defaultParams templates.GenerationParams
+	if apiRecord, _, _, err := loadSelectedModel(app); err == nil {
+		defaultParams = loadGenerationParams(apiRecord)
+	}
+
+	usage, err := threadUsage(id, app)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to total thread token usage")
+	}
+
+	threadParams := templates.LoadedThreadParams{
+		Id:                  id,
+		Title:               threadRecord.GetString("thread_title"),
+		SystemPrompt:        threadRecord.GetString("system_prompt"),
+		DefaultSystemPrompt: loadDefaultSystemPrompt(app),
+		RespondTo:           respondTo,
+		Context:             contextUsage,
+		GenerationParams:    loadGenerationParams(threadRecord),
+		Usage:               usage,
+		DefaultGenerationParams: defaultParams,
+		Tools:               threadToolOptions(threadRecord),
+	}
+
 	c.Response().Writer.WriteHeader(200)
-	loadedChat := templates.LoadedThread(threadRecord.GetString("thread_title"), messages)
+	loadedChat := templates.LoadedThread(threadParams, messages)
 	err = loadedChat.Render(context.Background(), c.Response().Writer)
 	if err != nil {
 		return c.String(http.StatusInternalServerError, "failed to render loaded chat response")
@@ -102,6 +147,17 @@ func DeleteThread(threadId string, c echo.Context, app *pocketbase.PocketBase) e
 		return c.String(http.StatusInternalServerError, "failed to fetch thread record to delete")
 	}
 	
+	// deleting through the DAO removes the attached files along with the records
+	attachmentRecords, err := app.Dao().FindRecordsByFilter("chat", "thread_id = {:thread} && attachments:length > 0", "", 0, 0, dbx.Params{"thread": threadId})
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to fetch chat messages with attachments")
+	}
+	for _, record := range attachmentRecords {
+		if err := app.Dao().DeleteRecord(record); err != nil {
+			return c.String(http.StatusInternalServerError, "failed to delete chat message attachments")
+		}
+	}
+
 	deleteQuery := "DELETE FROM chat WHERE thread_id ='" + threadId + "'"
 	fmt.Println(deleteQuery)
 	if _, err := app.Dao().DB().NewQuery(deleteQuery).Execute(); err != nil {
@@ -183,6 +239,7 @@ func SaveThreadTitle(id string, title string, c echo.Context, app *pocketbase.Po
 	}
 	if title != "" {
 		idRecord.Set("thread_title", title)
+		idRecord.Set("title_set_by_user", true)
 		if err := app.Dao().SaveRecord(idRecord); err != nil {
 			return c.String(http.StatusInternalServerError, "failed to update thread title record")
 		}
@@ -198,6 +255,27 @@ func SaveThreadTitle(id string, title string, c echo.Context, app *pocketbase.Po
 	return nil
 }
 
+func SaveThreadSystemPrompt(id string, prompt string, c echo.Context, app *pocketbase.PocketBase) error {
+	threadRecord, err := app.Dao().FindRecordById("chat_meta", id)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to find thread record to set system prompt")
+	}
+
+	threadRecord.Set("system_prompt", prompt)
+	if err := app.Dao().SaveRecord(threadRecord); err != nil {
+		return c.String(http.StatusInternalServerError, "failed to update thread system prompt")
+	}
+
+	c.Response().Writer.WriteHeader(200)
+	systemPromptStatus := templates.SystemPromptUpdated()
+	err = systemPromptStatus.Render(context.Background(), c.Response().Writer)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to render system prompt status")
+	}
+
+	return nil
+}
+
 func RemoveTagFromThread(threadId string, tagId string, c echo.Context, app *pocketbase.PocketBase) error {
 	threadRecord, err := app.Dao().FindRecordById("chat_meta", threadId)
 	if err != nil {
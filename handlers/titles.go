@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/erikmillergalow/htmx-llmchat/templates"
+
+	"github.com/labstack/echo/v5"
+	openai "github.com/sashabaranov/go-openai"
+
+	"github.com/pocketbase/pocketbase"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// threads waiting on a title, compare answers finish together but only one asks
+var titleRequests sync.Map
+
+// new threads are titled with their record id until renamed
+func threadNeedsTitle(threadRecord *models.Record) bool {
+	return !threadRecord.GetBool("title_set_by_user") && threadRecord.GetString("thread_title") == threadRecord.Id
+}
+
+// tidy up a title the model may have quoted or punctuated
+func cleanTitle(title string) string {
+	title, _, _ = strings.Cut(strings.TrimSpace(title), "\n")
+	title = strings.Trim(title, " \t\"'`*#")
+	if strings.HasPrefix(strings.ToLower(title), "title:") {
+		title = strings.Trim(title[len("title:"):], " \t\"'`*#")
+	}
+	title = strings.TrimRight(title, ".")
+	return truncateMessage(strings.TrimSpace(title), 80)
+}
+
+// ask the title model for a short title of the first exchange and push it to the sidebar
+// the API and model that answered are used when no title API is set
+func generateThreadTitle(generation chatGeneration, response string, socket componentWriter, app *pocketbase.PocketBase) {
+	settingsRecord, err := app.Dao().FindFirstRecordByData("settings", "type", "keys")
+	if err != nil || !settingsRecord.GetBool("auto_titles") {
+		return
+	}
+
+	if _, busy := titleRequests.LoadOrStore(generation.ThreadId, true); busy {
+		return
+	}
+	defer titleRequests.Delete(generation.ThreadId)
+
+	apiRecord, modelName := generation.ApiRecord, generation.ModelName
+	if titleApiId := settingsRecord.GetString("title_api"); titleApiId != "" {
+		titleApiRecord, err := app.Dao().FindRecordById("apis", titleApiId)
+		if err != nil {
+			fmt.Printf("Failed to find title API: %v\n", err)
+			return
+		}
+		apiRecord, modelName = titleApiRecord, settingsRecord.GetString("title_model")
+	}
+
+	prompt := ""
+	for _, message := range generation.History {
+		if message.Role == openai.ChatMessageRoleUser {
+			prompt = messageText(message)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	release, err := apiLimits.acquire(ctx, apiRecord, nil)
+	if err != nil {
+		fmt.Printf("Gave up waiting for the title API: %v\n", err)
+		return
+	}
+	defer release()
+
+	provider, err := newProvider(apiRecord)
+	if err != nil {
+		fmt.Printf("Failed to generate thread title: %v\n", err)
+		return
+	}
+	titleResponse, err := provider.Chat(ctx, openai.ChatCompletionRequest{
+		Model: modelName,
+		Messages: []openai.ChatCompletionMessage{
+			{
+				Role:    openai.ChatMessageRoleSystem,
+				Content: "Write a short title of at most six words for the conversation below. Reply with the title only, without quotes.",
+			},
+			{
+				Role:    openai.ChatMessageRoleUser,
+				Content: "User: " + truncateMessage(prompt, 2000) + "\n\nAssistant: " + truncateMessage(response, 2000),
+			},
+		},
+		MaxTokens: 32,
+	})
+	if err != nil {
+		fmt.Printf("Failed to generate thread title: %v\n", err)
+		return
+	}
+	if len(titleResponse.Choices) == 0 {
+		return
+	}
+	// a reasoning model's thinking is no part of the title
+	content, _ := splitReasoning(titleResponse.Choices[0].Message.Content, opensThinkBlock(apiRecord))
+	title := cleanTitle(content)
+	if title == "" {
+		return
+	}
+
+	// the user may have renamed the thread while the title was being written
+	threadRecord, err := app.Dao().FindRecordById("chat_meta", generation.ThreadId)
+	if err != nil || !threadNeedsTitle(threadRecord) {
+		return
+	}
+	threadRecord.Set("thread_title", title)
+	if err := app.Dao().SaveRecord(threadRecord); err != nil {
+		fmt.Printf("Failed to save generated thread title: %v\n", err)
+		return
+	}
+
+	if err := socket.writeComponent(templates.ThreadTitleSwap(generation.ThreadId, title)); err != nil {
+		fmt.Printf("Failed to push generated thread title: %v\n", err)
+	}
+}
+
+func SaveTitleSettings(data map[string]any, c echo.Context, app *pocketbase.PocketBase) error {
+	settingsRecord, err := app.Dao().FindFirstRecordByData("settings", "type", "keys")
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to find settings record")
+	}
+
+	settingsRecord.Set("auto_titles", data["auto-titles"] != nil)
+	settingsRecord.Set("title_api", FormValue(data, "title-api"))
+	settingsRecord.Set("title_model", strings.TrimSpace(FormValue(data, "title-model")))
+	if err := app.Dao().SaveRecord(settingsRecord); err != nil {
+		return c.String(http.StatusInternalServerError, "failed to update title settings")
+	}
+
+	c.Response().Writer.WriteHeader(200)
+	settingsUpdated := templates.SettingsUpdated()
+	err = settingsUpdated.Render(context.Background(), c.Response().Writer)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to render settings update response")
+	}
+
+	return nil
+}
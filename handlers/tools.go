@@ -0,0 +1,757 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/erikmillergalow/htmx-llmchat/templates"
+
+	"github.com/labstack/echo/v5"
+	openai "github.com/sashabaranov/go-openai"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// a Go function a model can call while answering
+type chatTool struct {
+	Name        string
+	Description string
+	// JSON schema of the arguments
+	Parameters map[string]any
+	// dangerous tools only run once the user allows the call
+	Dangerous bool
+	Run       func(ctx context.Context, arguments string, app *pocketbase.PocketBase) (string, error)
+}
+
+// rounds of tool calls a single answer may make before it is cut off
+const maxToolRounds = 8
+
+// tool results past this many characters are cut off
+const maxToolResult = 20000
+
+const (
+	toolRunTimeout      = 30 * time.Second
+	toolApprovalTimeout = 10 * time.Minute
+)
+
+var chatTools = []chatTool{
+	{
+		Name:        "calculator",
+		Description: "Evaluate an arithmetic expression. Supports + - * / % ^, parentheses, pi, e and the functions sqrt, abs, ln, log, log2, exp, sin, cos, tan, asin, acos, atan, floor, ceil and round.",
+		Parameters: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"expression": map[string]any{"type": "string", "description": "Expression to evaluate, for example (2 + 3) * sqrt(16)"},
+			},
+			"required": []string{"expression"},
+		},
+		Run: runCalculatorTool,
+	},
+	{
+		Name:        "current_time",
+		Description: "Get the current date and time.",
+		Parameters: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"timezone": map[string]any{"type": "string", "description": "IANA time zone such as Europe/Paris, the local time zone when left out"},
+			},
+		},
+		Run: runCurrentTimeTool,
+	},
+	{
+		Name:        "read_file",
+		Description: "Read a text file, or list a directory, inside the sandbox directory set up by the user. Paths are relative to the sandbox.",
+		Parameters: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"path": map[string]any{"type": "string", "description": "Path relative to the sandbox directory, . for the sandbox itself"},
+			},
+			"required": []string{"path"},
+		},
+		Dangerous: true,
+		Run:       runReadFileTool,
+	},
+	{
+		Name: "query_threads",
+		Description: "Run a read-only SQLite SELECT over the user's chat history. Tables: " +
+			"chat_meta (id, thread_title, created, last_message_timestamp, tags, system_prompt), " +
+			"chat (id, thread_id, parent_id, sender, model, message, created, useful, total_tokens), " +
+			"tags (id, value, color). chat.sender is human, model, tool or system. At most 100 rows are returned.",
+		Parameters: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"sql": map[string]any{"type": "string", "description": "A single SELECT statement"},
+			},
+			"required": []string{"sql"},
+		},
+		Run: runQueryThreadsTool,
+	},
+}
+
+func findChatTool(tools []chatTool, name string) (chatTool, bool) {
+	index := slices.IndexFunc(tools, func(tool chatTool) bool { return tool.Name == name })
+	if index < 0 {
+		return chatTool{}, false
+	}
+	return tools[index], true
+}
+
+// tools switched on for a thread, in registry order
+func threadTools(threadRecord *models.Record) []chatTool {
+	var names []string
+	raw := threadRecord.GetString("tools")
+	if raw != "" && raw != "null" {
+		if err := json.Unmarshal([]byte(raw), &names); err != nil {
+			fmt.Printf("Failed to read thread tools: %v\n", err)
+		}
+	}
+
+	var tools []chatTool
+	for _, tool := range chatTools {
+		if slices.Contains(names, tool.Name) {
+			tools = append(tools, tool)
+		}
+	}
+	return tools
+}
+
+func threadToolOptions(threadRecord *models.Record) []templates.ToolOption {
+	enabled := threadTools(threadRecord)
+
+	var options []templates.ToolOption
+	for _, tool := range chatTools {
+		_, on := findChatTool(enabled, tool.Name)
+		options = append(options, templates.ToolOption{
+			Name:        tool.Name,
+			Description: tool.Description,
+			Dangerous:   tool.Dangerous,
+			Enabled:     on,
+		})
+	}
+	return options
+}
+
+func requestTools(tools []chatTool) []openai.Tool {
+	var requestTools []openai.Tool
+	for _, tool := range tools {
+		requestTools = append(requestTools, openai.Tool{
+			Type: openai.ToolTypeFunction,
+			Function: &openai.FunctionDefinition{
+				Name:        tool.Name,
+				Description: tool.Description,
+				Parameters:  tool.Parameters,
+			},
+		})
+	}
+	return requestTools
+}
+
+// streamed tool calls arrive in pieces, joined here by their index
+func mergeToolCallDeltas(calls []openai.ToolCall, deltas []openai.ToolCall) []openai.ToolCall {
+	for _, delta := range deltas {
+		// some servers send each call whole and without an index
+		index := len(calls)
+		if delta.Index != nil {
+			index = *delta.Index
+		}
+		for len(calls) <= index {
+			calls = append(calls, openai.ToolCall{Type: openai.ToolTypeFunction})
+		}
+
+		call := &calls[index]
+		if delta.ID != "" {
+			call.ID = delta.ID
+		}
+		call.Function.Name += delta.Function.Name
+		call.Function.Arguments += delta.Function.Arguments
+	}
+	return calls
+}
+
+// tool calls as they are stored on the model message, local servers don't always give them ids
+func storedToolCalls(calls []openai.ToolCall) templates.ToolCallList {
+	var stored templates.ToolCallList
+	for _, call := range calls {
+		if call.Function.Name == "" {
+			continue
+		}
+		if call.ID == "" {
+			call.ID = "call_" + security.RandomString(16)
+		}
+		stored = append(stored, templates.ToolCallParams{
+			Id:        call.ID,
+			Name:      call.Function.Name,
+			Arguments: call.Function.Arguments,
+		})
+	}
+	return stored
+}
+
+// the assistant message that made the calls, only calls answered further along the path are kept
+func modelContextMessage(message templates.LoadedMessageParams, answered map[string]bool) contextMessage {
+	contextMessage := newContextMessage(message.Id, openai.ChatMessageRoleAssistant, message.Message)
+	for _, call := range message.ToolCalls {
+		if !answered[call.Id] {
+			continue
+		}
+		contextMessage.Message.ToolCalls = append(contextMessage.Message.ToolCalls, openai.ToolCall{
+			ID:   call.Id,
+			Type: openai.ToolTypeFunction,
+			Function: openai.FunctionCall{
+				Name:      call.Name,
+				Arguments: call.Arguments,
+			},
+		})
+		contextMessage.Tokens += estimateTokens(call.Name + " " + call.Arguments)
+	}
+	return contextMessage
+}
+
+func toolContextMessage(message templates.LoadedMessageParams) contextMessage {
+	contextMessage := newContextMessage(message.Id, openai.ChatMessageRoleTool, message.Message)
+	contextMessage.Message.ToolCallID = message.ToolCall.Id
+	return contextMessage
+}
+
+// calls waiting on the user, answered from the socket
+type toolApprovalRegistry struct {
+	mu      sync.Mutex
+	waiting map[string]chan bool
+}
+
+var toolApprovals = &toolApprovalRegistry{waiting: make(map[string]chan bool)}
+
+// block until the user allows or denies the call, stopping the generation denies it
+func (r *toolApprovalRegistry) wait(ctx context.Context, toolMessageId string) bool {
+	answer := make(chan bool, 1)
+	r.mu.Lock()
+	r.waiting[toolMessageId] = answer
+	r.mu.Unlock()
+
+	defer func() {
+		r.mu.Lock()
+		delete(r.waiting, toolMessageId)
+		r.mu.Unlock()
+	}()
+
+	select {
+	case approved := <-answer:
+		return approved
+	case <-ctx.Done():
+		return false
+	case <-time.After(toolApprovalTimeout):
+		return false
+	}
+}
+
+func (r *toolApprovalRegistry) answer(toolMessageId string, approved bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if answer, ok := r.waiting[toolMessageId]; ok {
+		select {
+		case answer <- approved:
+		default:
+		}
+	}
+}
+
+func toolMessageParams(record *models.Record, call templates.ToolCallParams) templates.LoadedMessageParams {
+	return templates.LoadedMessageParams{
+		Id:         record.Id,
+		Message:    record.GetString("message"),
+		Sender:     "tool",
+		ThreadId:   record.GetString("thread_id"),
+		ParentId:   record.GetString("parent_id"),
+		ToolCall:   call,
+		ToolStatus: record.GetString("tool_status"),
+	}
+}
+
+// run the calls a model message made, each stored as a tool message after the last
+// returns the id of the last tool message
+func runToolCalls(ctx context.Context, generation chatGeneration, calls templates.ToolCallList, job *generationJob, app *pocketbase.PocketBase) (string, error) {
+	chatCollection, err := app.Dao().FindCollectionByNameOrId("chat")
+	if err != nil {
+		return "", err
+	}
+
+	parentId := generation.Record.Id
+	for _, call := range calls {
+		tool, known := findChatTool(generation.Tools, call.Name)
+
+		status := "running"
+		if known && tool.Dangerous {
+			status = "approval"
+		}
+
+		toolRecord := models.NewRecord(chatCollection)
+		form := forms.NewRecordUpsert(app, toolRecord)
+		form.LoadData(map[string]any{
+			"thread_id":   generation.ThreadId,
+			"parent_id":   parentId,
+			"message":     "",
+			"sender":      "tool",
+			"tool_call":   call,
+			"tool_status": status,
+		})
+		if err := form.Submit(); err != nil {
+			return "", fmt.Errorf("failed to store tool call: %w", err)
+		}
+		parentId = toolRecord.Id
+
+		if err := job.writeComponent(templates.InitToolMessage(toolMessageParams(toolRecord, call))); err != nil {
+			fmt.Println("socket write failure")
+			fmt.Println(err)
+		}
+
+		// hide the buttons once the call is allowed
+		if status == "approval" && toolApprovals.wait(ctx, toolRecord.Id) {
+			status = "running"
+			toolRecord.Set("tool_status", status)
+			if err := app.Dao().SaveRecord(toolRecord); err != nil {
+				return "", fmt.Errorf("failed to store tool status: %w", err)
+			}
+			if err := job.writeComponent(templates.ToolMessageSwap(toolMessageParams(toolRecord, call))); err != nil {
+				fmt.Println("socket write failure")
+				fmt.Println(err)
+			}
+		}
+
+		var result string
+		switch {
+		case !known:
+			status, result = "error", "Error: the tool "+call.Name+" is not available"
+		case status == "approval":
+			status, result = "denied", "The user did not allow this tool call."
+		default:
+			runCtx, cancel := context.WithTimeout(ctx, toolRunTimeout)
+			output, err := tool.Run(runCtx, call.Arguments, app)
+			cancel()
+			if err != nil {
+				status, result = "error", "Error: "+err.Error()
+			} else {
+				status, result = "done", truncateMessage(output, maxToolResult)
+			}
+		}
+
+		toolRecord.Set("message", result)
+		toolRecord.Set("tool_status", status)
+		if err := app.Dao().SaveRecord(toolRecord); err != nil {
+			return "", fmt.Errorf("failed to store tool result: %w", err)
+		}
+
+		if err := job.writeComponent(templates.ToolMessageSwap(toolMessageParams(toolRecord, call))); err != nil {
+			fmt.Println("socket write failure")
+			fmt.Println(err)
+		}
+
+		// the calls left are dropped from the context since they have no results
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	return parentId, nil
+}
+
+// a model message answering the results of the tools, streamed like any other response
+// it is announced through the job of the message that called the tools
+func continueAfterTools(ctx context.Context, generation chatGeneration, parentId string, job *generationJob, app *pocketbase.PocketBase) (chatGeneration, error) {
+	chatCollection, err := app.Dao().FindCollectionByNameOrId("chat")
+	if err != nil {
+		return generation, err
+	}
+
+	modelMessageRecord := models.NewRecord(chatCollection)
+	modelForm := forms.NewRecordUpsert(app, modelMessageRecord)
+	modelForm.LoadData(map[string]any{
+		"thread_id": generation.ThreadId,
+		"parent_id": parentId,
+		"message":   "",
+		"sender":    "model",
+		"model":     generation.ChatModelName,
+	})
+	if err := modelForm.Submit(); err != nil {
+		return generation, fmt.Errorf("failed to initialize model message in chat DB: %w", err)
+	}
+
+	chatParams := templates.LoadedMessageParams{
+		Id:               modelMessageRecord.Id,
+		Model:            generation.ChatModelName,
+		ParentId:         parentId,
+		GenerationParams: generation.Params,
+	}
+	if err := job.writeComponent(templates.InitModelMessage(chatParams)); err != nil {
+		fmt.Println("socket write failure")
+		fmt.Println(err)
+	}
+
+	chatHistory, err := buildChatHistory(generation.ThreadId, parentId, app)
+	if err != nil {
+		return generation, err
+	}
+	chatHistory.fit(ctx, generation.ApiRecord, generation.ModelName, false, app)
+
+	generation.Record = modelMessageRecord
+	generation.History = chatHistory.history()
+	generation.PromptTokens = chatHistory.tokens()
+	return generation, nil
+}
+
+// stream a response, and while it calls tools run them and stream the answer to their results
+// the job of a message stays open while its tools run, so a reconnecting client still gets
+// the tool calls and approval prompts, and the next message's job takes over its sockets
+func runChatGeneration(ctx context.Context, generation chatGeneration, socket *chatSocket, app *pocketbase.PocketBase) {
+	job := generationJobs.start(generation.ThreadId, generation.Record.Id, socket)
+	defer func() {
+		generationJobs.finish(job)
+	}()
+
+	for round := 1; ; round++ {
+		calls := streamChatGeneration(ctx, generation, job, app)
+		if len(calls) == 0 || ctx.Err() != nil {
+			return
+		}
+		if round > maxToolRounds {
+			err := fmt.Errorf("stopped after %d rounds of tool calls", maxToolRounds)
+			handleChatError(err, job, generation.ThreadId, app)
+			return
+		}
+
+		lastId, err := runToolCalls(ctx, generation, calls, job, app)
+		if err != nil {
+			handleChatError(err, job, generation.ThreadId, app)
+			return
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		generation, err = continueAfterTools(ctx, generation, lastId, job, app)
+		if err != nil {
+			handleChatError(err, job, generation.ThreadId, app)
+			return
+		}
+		next := generationJobs.handOver(job, generation.Record.Id)
+		generationJobs.finish(job)
+		job = next
+	}
+}
+
+func runCalculatorTool(ctx context.Context, arguments string, app *pocketbase.PocketBase) (string, error) {
+	var args struct {
+		Expression string `json:"expression"`
+	}
+	if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+		return "", fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	result, err := evaluateExpression(args.Expression)
+	if err != nil {
+		return "", err
+	}
+	return formatCalculatorResult(result), nil
+}
+
+func runCurrentTimeTool(ctx context.Context, arguments string, app *pocketbase.PocketBase) (string, error) {
+	var args struct {
+		Timezone string `json:"timezone"`
+	}
+	if strings.TrimSpace(arguments) != "" {
+		if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+			return "", fmt.Errorf("invalid arguments: %w", err)
+		}
+	}
+
+	location := time.Local
+	if args.Timezone != "" {
+		var err error
+		if location, err = time.LoadLocation(args.Timezone); err != nil {
+			return "", fmt.Errorf("unknown time zone %q", args.Timezone)
+		}
+	}
+	return time.Now().In(location).Format("Monday, 2 January 2006 15:04:05 MST (-07:00)"), nil
+}
+
+// resolve a path inside the sandbox, following links only as far as they stay inside it
+func sandboxPath(sandbox string, path string) (string, error) {
+	root, err := filepath.EvalSymlinks(filepath.Clean(sandbox))
+	if err != nil {
+		return "", fmt.Errorf("the sandbox directory can't be read: %w", err)
+	}
+
+	target, err := filepath.EvalSymlinks(filepath.Join(root, filepath.Clean("/"+path)))
+	if err != nil {
+		return "", fmt.Errorf("%s not found", path)
+	}
+
+	relative, err := filepath.Rel(root, target)
+	if err != nil || relative == ".." || strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is outside the sandbox", path)
+	}
+	return target, nil
+}
+
+func runReadFileTool(ctx context.Context, arguments string, app *pocketbase.PocketBase) (string, error) {
+	var args struct {
+		Path string `json:"path"`
+	}
+	if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+		return "", fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	settingsRecord, err := app.Dao().FindFirstRecordByData("settings", "type", "keys")
+	if err != nil {
+		return "", fmt.Errorf("failed to read settings: %w", err)
+	}
+	sandbox := settingsRecord.GetString("tool_sandbox_dir")
+	if sandbox == "" {
+		return "", errors.New("no sandbox directory is set up, it can be chosen in the config menu")
+	}
+
+	path, err := sandboxPath(sandbox, args.Path)
+	if err != nil {
+		return "", err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("%s not found", args.Path)
+	}
+
+	if info.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return "", err
+		}
+		var names []string
+		for _, entry := range entries {
+			name := entry.Name()
+			if entry.IsDir() {
+				name += "/"
+			}
+			names = append(names, name)
+		}
+		if len(names) == 0 {
+			return "(empty directory)", nil
+		}
+		return strings.Join(names, "\n"), nil
+	}
+
+	if info.Size() > 20<<20 {
+		return "", fmt.Errorf("%s is too large to read", args.Path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	// same text extraction as attachments
+	attachment := extractAttachment(info.Name(), data)
+	if attachment.IsImage() {
+		return "[image file, contents not included]", nil
+	}
+	return attachment.Text, nil
+}
+
+// tables a query may read, the settings and apis hold keys that shouldn't reach a model
+var queryableTables = []string{"chat", "chat_meta", "tags"}
+
+func runQueryThreadsTool(ctx context.Context, arguments string, app *pocketbase.PocketBase) (string, error) {
+	var args struct {
+		Sql string `json:"sql"`
+	}
+	if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+		return "", fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	query := strings.TrimSuffix(strings.TrimSpace(args.Sql), ";")
+	lower := strings.ToLower(query)
+	if !strings.HasPrefix(lower, "select") && !strings.HasPrefix(lower, "with") {
+		return "", errors.New("only SELECT statements can be run")
+	}
+	if strings.Contains(query, ";") {
+		return "", errors.New("only a single statement can be run")
+	}
+
+	// a connection of its own that can't write, whatever the query does
+	db, err := dbx.Open(app.DB().DriverName(), "file:"+filepath.Join(app.DataDir(), "data.db")+"?mode=ro")
+	if err != nil {
+		return "", err
+	}
+	defer db.Close()
+
+	if err := checkQueryTables(db, query); err != nil {
+		return "", err
+	}
+
+	rows, err := db.NewQuery(query).WithContext(ctx).Rows()
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	return formatQueryRows(rows.Rows)
+}
+
+// a step of the program SQLite compiles a statement into
+type queryOpcode struct {
+	Opcode string `db:"opcode"`
+	// root page of the table or index for the open opcodes
+	P2 int `db:"p2"`
+	// database the table is in, 0 for main
+	P3 int `db:"p3"`
+}
+
+// refuse a query that reads any table besides the queryable ones
+// the tables come from the program SQLite compiled, so joins, subqueries and views are all covered
+func checkQueryTables(db *dbx.DB, query string) error {
+	var schema []struct {
+		Table    string `db:"tbl_name"`
+		RootPage int    `db:"rootpage"`
+	}
+	err := db.NewQuery("SELECT tbl_name, rootpage FROM sqlite_master WHERE type IN ('table', 'index')").All(&schema)
+	if err != nil {
+		return err
+	}
+	tables := map[int]string{}
+	for _, entry := range schema {
+		tables[entry.RootPage] = entry.Table
+	}
+
+	var program []queryOpcode
+	if err := db.NewQuery("EXPLAIN " + query).All(&program); err != nil {
+		return err
+	}
+	for _, step := range program {
+		switch step.Opcode {
+		case "OpenRead", "ReopenIdx":
+		case "OpenWrite":
+			return errors.New("only SELECT statements can be run")
+		default:
+			continue
+		}
+		table, ok := tables[step.P2]
+		if step.P3 != 0 || !ok {
+			return fmt.Errorf("the query reads a table that can't be queried, use %s", strings.Join(queryableTables, ", "))
+		}
+		if !slices.Contains(queryableTables, table) {
+			return fmt.Errorf("the table %s can't be queried, use %s", table, strings.Join(queryableTables, ", "))
+		}
+	}
+	return nil
+}
+
+// rows as a pipe separated table, at most 100 of them
+func formatQueryRows(rows *sql.Rows) (string, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return "", err
+	}
+
+	var lines []string
+	lines = append(lines, strings.Join(columns, " | "))
+
+	count := 0
+	for rows.Next() {
+		count++
+		if count > 100 {
+			continue
+		}
+
+		values := make([]sql.NullString, len(columns))
+		pointers := make([]any, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return "", err
+		}
+
+		var cells []string
+		for _, value := range values {
+			cell := "NULL"
+			if value.Valid {
+				cell = strings.ReplaceAll(truncateMessage(value.String, 500), "\n", " ")
+			}
+			cells = append(cells, cell)
+		}
+		lines = append(lines, strings.Join(cells, " | "))
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+
+	if count == 0 {
+		return "no rows", nil
+	}
+	if count > 100 {
+		lines = append(lines, fmt.Sprintf("(%d more rows not shown)", count-100))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func SaveThreadTools(id string, data map[string]any, c echo.Context, app *pocketbase.PocketBase) error {
+	threadRecord, err := app.Dao().FindRecordById("chat_meta", id)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to find thread record to set tools")
+	}
+
+	// unchecked boxes aren't sent
+	names := []string{}
+	for _, tool := range chatTools {
+		if data["tool-"+tool.Name] != nil {
+			names = append(names, tool.Name)
+		}
+	}
+
+	threadRecord.Set("tools", names)
+	if err := app.Dao().SaveRecord(threadRecord); err != nil {
+		return c.String(http.StatusInternalServerError, "failed to update thread tools")
+	}
+
+	c.Response().Writer.WriteHeader(200)
+	toolsStatus := templates.ToolsStatus("Tools updated")
+	err = toolsStatus.Render(context.Background(), c.Response().Writer)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to render tools status")
+	}
+
+	return nil
+}
+
+func SaveToolSettings(data map[string]any, c echo.Context, app *pocketbase.PocketBase) error {
+	settingsRecord, err := app.Dao().FindFirstRecordByData("settings", "type", "keys")
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to find settings record")
+	}
+
+	settingsRecord.Set("tool_sandbox_dir", strings.TrimSpace(FormValue(data, "tool-sandbox-dir")))
+	if err := app.Dao().SaveRecord(settingsRecord); err != nil {
+		return c.String(http.StatusInternalServerError, "failed to update tool settings")
+	}
+
+	c.Response().Writer.WriteHeader(200)
+	settingsUpdated := templates.SettingsUpdated()
+	err = settingsUpdated.Render(context.Background(), c.Response().Writer)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to render settings update response")
+	}
+
+	return nil
+}
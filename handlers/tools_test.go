@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase"
+)
+
+// an app with the tables the query tool can see and some it must not
+func newQueryTestApp(t *testing.T) *pocketbase.PocketBase {
+	app := pocketbase.NewWithConfig(pocketbase.Config{DefaultDataDir: t.TempDir()})
+	if err := app.Bootstrap(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { app.ResetBootstrapState() })
+
+	for _, statement := range []string{
+		"CREATE TABLE chat (id TEXT PRIMARY KEY, thread_id TEXT, sender TEXT, message TEXT)",
+		"CREATE INDEX idx_chat_thread ON chat (thread_id)",
+		"CREATE TABLE chat_meta (id TEXT PRIMARY KEY, thread_title TEXT, tags JSON)",
+		"CREATE TABLE tags (id TEXT PRIMARY KEY, value TEXT)",
+		"CREATE TABLE apis (id TEXT PRIMARY KEY, api_key TEXT)",
+		"CREATE TABLE settings (id TEXT PRIMARY KEY, openai_key TEXT)",
+		"CREATE TABLE _users (id TEXT PRIMARY KEY, email TEXT)",
+		"CREATE VIEW api_keys AS SELECT api_key FROM apis",
+		"INSERT INTO chat VALUES ('m1', 't1', 'human', 'hello')",
+		`INSERT INTO chat_meta VALUES ('t1', 'greetings', '["g1"]')`,
+		"INSERT INTO tags VALUES ('g1', 'golang')",
+		"INSERT INTO apis VALUES ('a1', 'sk-secret')",
+	} {
+		if _, err := app.Dao().DB().NewQuery(statement).Execute(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return app
+}
+
+func runQuery(app *pocketbase.PocketBase, query string) (string, error) {
+	arguments, _ := json.Marshal(map[string]string{"sql": query})
+	return runQueryThreadsTool(context.Background(), string(arguments), app)
+}
+
+func TestQueryThreadsToolRejectsOtherTables(t *testing.T) {
+	app := newQueryTestApp(t)
+
+	for _, query := range []string{
+		"SELECT api_key FROM chat, apis",
+		"SELECT * FROM chat c, settings s",
+		"SELECT * FROM chat, _users",
+		"SELECT * FROM apis",
+		"SELECT * FROM api_keys",
+		"SELECT id FROM chat WHERE id IN (SELECT api_key FROM apis)",
+		"SELECT (SELECT api_key FROM apis LIMIT 1) FROM chat",
+		"WITH k AS (SELECT api_key FROM apis) SELECT * FROM chat, k",
+		"SELECT * FROM chat UNION SELECT id, api_key, '', '' FROM apis",
+		"SELECT * FROM sqlite_master",
+		"WITH x AS (SELECT 1) DELETE FROM chat",
+		"SELECT 1; DELETE FROM chat",
+		"DELETE FROM chat",
+	} {
+		result, err := runQuery(app, query)
+		if err == nil {
+			t.Errorf("query %q should be rejected, got %q", query, result)
+		}
+		if strings.Contains(result, "sk-secret") {
+			t.Errorf("query %q leaked an API key", query)
+		}
+	}
+
+	var count int
+	if err := app.Dao().DB().NewQuery("SELECT COUNT(*) FROM chat").Row(&count); err != nil || count != 1 {
+		t.Errorf("chat should be left untouched, has %d rows (%v)", count, err)
+	}
+}
+
+func TestQueryThreadsToolReadsThreads(t *testing.T) {
+	app := newQueryTestApp(t)
+
+	for query, want := range map[string]string{
+		"SELECT message FROM chat WHERE thread_id = 't1'":                                       "message\nhello",
+		"SELECT m.thread_title, c.message FROM chat_meta m JOIN chat c ON c.thread_id = m.id":   "thread_title | message\ngreetings | hello",
+		"SELECT t.value FROM chat_meta m, json_each(m.tags) j JOIN tags t ON t.id = j.value":    "value\ngolang",
+		"WITH recent AS (SELECT * FROM chat) SELECT COUNT(*) AS messages FROM recent;":          "messages\n1",
+		"SELECT thread_title FROM chat_meta WHERE id IN (SELECT thread_id FROM chat) AND 1 = 0": "no rows",
+	} {
+		result, err := runQuery(app, query)
+		if err != nil {
+			t.Errorf("query %q failed: %v", query, err)
+			continue
+		}
+		if result != want {
+			t.Errorf("query %q = %q, want %q", query, result, want)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/erikmillergalow/htmx-llmchat/templates"
+
+	openai "github.com/sashabaranov/go-openai"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase"
+)
+
+// token usage reported in the last chunk of a stream
+type streamUsage struct {
+	mu    sync.Mutex
+	usage *openai.Usage
+}
+
+func (u *streamUsage) set(usage openai.Usage) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.usage = &usage
+}
+
+func (u *streamUsage) get() *openai.Usage {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.usage
+}
+
+// asks for usage on streamed chat and text completions and reads it from the final chunk
+// the go-openai version in use has neither stream_options nor usage on stream responses
+type streamUsageTransport struct {
+	base  http.RoundTripper
+	usage *streamUsage
+}
+
+func (t *streamUsageTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodPost || !strings.HasSuffix(req.URL.Path, "/completions") || req.Body == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	// numbers are kept as written so large seeds survive the round trip
+	var payload map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if decoder.Decode(&payload) == nil && payload["stream"] == true {
+		payload["stream_options"] = map[string]any{"include_usage": true}
+		if updated, err := json.Marshal(payload); err == nil {
+			body = updated
+		}
+	}
+
+	req = req.Clone(req.Context())
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+
+	response, err := t.base.RoundTrip(req)
+	if err != nil || response.StatusCode != http.StatusOK {
+		return response, err
+	}
+	response.Body = &dataLineReader{ReadCloser: response.Body, onData: t.readUsage}
+	return response, nil
+}
+
+func (t *streamUsageTransport) readUsage(data []byte) {
+	if !bytes.Contains(data, []byte(`"usage"`)) {
+		return
+	}
+	var chunk struct {
+		Usage *openai.Usage `json:"usage"`
+	}
+	if json.Unmarshal(data, &chunk) == nil && chunk.Usage != nil && chunk.Usage.TotalTokens > 0 {
+		t.usage.set(*chunk.Usage)
+	}
+}
+
+// passes an event stream through while handing the data of each event to onData
+type dataLineReader struct {
+	io.ReadCloser
+	onData  func(data []byte)
+	pending []byte
+}
+
+func (r *dataLineReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.pending = append(r.pending, p[:n]...)
+
+	for {
+		end := bytes.IndexByte(r.pending, '\n')
+		if end < 0 {
+			break
+		}
+		line := bytes.TrimSpace(r.pending[:end])
+		r.pending = r.pending[end+1:]
+
+		if data, ok := bytes.CutPrefix(line, []byte("data:")); ok {
+			r.onData(bytes.TrimSpace(data))
+		}
+	}
+
+	return n, err
+}
+
+// usage of a finished response, estimated locally when the API didn't report it
+func responseUsage(reported *openai.Usage, promptTokens int, response string) (openai.Usage, bool) {
+	if reported != nil {
+		return *reported, false
+	}
+
+	completionTokens := estimateTokens(response)
+	return openai.Usage{
+		PromptTokens:     promptTokens,
+		CompletionTokens: completionTokens,
+		TotalTokens:      promptTokens + completionTokens,
+	}, true
+}
+
+// tokens used by every response in a thread, including other branches
+func threadUsage(threadId string, app *pocketbase.PocketBase) (templates.TokenUsageParams, error) {
+	var usage templates.TokenUsageParams
+	err := app.Dao().DB().
+		Select(
+			"COALESCE(SUM(prompt_tokens), 0) AS prompt_tokens",
+			"COALESCE(SUM(completion_tokens), 0) AS completion_tokens",
+			"COALESCE(SUM(total_tokens), 0) AS total_tokens",
+			"COALESCE(MAX(usage_estimated), FALSE) AS usage_estimated",
+		).
+		From("chat").
+		Where(dbx.HashExp{"thread_id": threadId}).
+		One(&usage)
+
+	return usage, err
+}
@@ -32,6 +32,9 @@ func main() {
 	// handle initial DB setup on first launch
 	migratecmd.MustRegister(app, app.RootCmd, migratecmd.Config{Automigrate: true})
 
+	// keep the full-text search index in step with messages and thread titles
+	handlers.RegisterSearchIndexHooks(app)
+
 	selectedModel := "openai"
 
 	app.OnBeforeServe().Add(func(e *core.ServeEvent) error {
@@ -55,6 +58,74 @@ func main() {
 			return handlers.ToggleMessageUsefulness(messageId, c, app)
 		})
 
+		// open editor for a human message
+		e.Router.GET("/chat/edit/:messageId", func(c echo.Context) error {
+			messageId := c.PathParam("messageId")
+			return handlers.EditMessage(messageId, c, app)
+		})
+
+		// save edited human message as a new branch
+		e.Router.POST("/chat/edit/:messageId", func(c echo.Context) error {
+			messageId := c.PathParam("messageId")
+			data := apis.RequestInfo(c).Data
+			message := handlers.FormValue(data, "message")
+			return handlers.SaveEditedMessage(messageId, message, c, app)
+		})
+
+		// switch a fork in the thread to one of its other branches
+		e.Router.POST("/chat/sibling/:messageId", func(c echo.Context) error {
+			messageId := c.PathParam("messageId")
+			return handlers.SelectSibling(messageId, c, app)
+		})
+
+		// upload files to attach to the next message
+		e.Router.POST("/chat/attachments", func(c echo.Context) error {
+			draftId := c.Request().FormValue("attachment-draft")
+			return handlers.UploadAttachments(draftId, c, app)
+		})
+
+		// remove a file from the next message
+		e.Router.DELETE("/chat/attachments/:draftId/:file", func(c echo.Context) error {
+			draftId := c.PathParam("draftId")
+			file := c.PathParam("file")
+			return handlers.RemoveAttachment(draftId, file, c, app)
+		})
+
+		// serve a file attached to a message
+		e.Router.GET("/chat/attachments/:messageId/:file", func(c echo.Context) error {
+			messageId := c.PathParam("messageId")
+			file := c.PathParam("file")
+			return handlers.ServeAttachment(messageId, file, c, app)
+		})
+
+		// keep one answer of a compare prompt
+		e.Router.POST("/chat/compare/keep/:messageId", func(c echo.Context) error {
+			messageId := c.PathParam("messageId")
+			return handlers.KeepCompareAnswer(messageId, c, app)
+		})
+
+		// keep every answer of a compare prompt side by side
+		e.Router.POST("/chat/compare/:messageId/all", func(c echo.Context) error {
+			messageId := c.PathParam("messageId")
+			return handlers.KeepAllCompareAnswers(messageId, c, app)
+		})
+
+		// list the models a compare prompt is sent to
+		e.Router.GET("/compare", func(c echo.Context) error {
+			return handlers.GetCompareModels(c, app)
+		})
+
+		// add the selected API and model to the compare list
+		e.Router.POST("/compare", func(c echo.Context) error {
+			return handlers.AddCompareModel(c, app)
+		})
+
+		// remove a model from the compare list
+		e.Router.DELETE("/compare/:index", func(c echo.Context) error {
+			index := c.PathParam("index")
+			return handlers.RemoveCompareModel(index, c, app)
+		})
+
 		// populate threads list in sidebar
 		e.Router.GET("/threads", func(c echo.Context) error {
 			return handlers.GetThreadList("creation", c, app)
@@ -66,6 +137,11 @@ func main() {
 			return handlers.GetThread(threadId, c, app)
 		})
 
+		// open a thread at a message found by search
+		e.Router.GET("/thread/:id/message/:messageId", func(c echo.Context) error {
+			return handlers.JumpToMessage(c.PathParam("id"), c.PathParam("messageId"), c, app)
+		})
+
 		// open thread title editor
 		e.Router.GET("/thread/title/:id", func(c echo.Context) error {
 			id := c.PathParam("id")
@@ -80,6 +156,37 @@ func main() {
 			return handlers.SaveThreadTitle(id, title, c, app)
 		})
 
+		// update thread system prompt
+		e.Router.PUT("/thread/system-prompt/:id", func(c echo.Context) error {
+			id := c.PathParam("id")
+			data := apis.RequestInfo(c).Data
+			prompt := handlers.FormValue(data, "system-prompt")
+			return handlers.SaveThreadSystemPrompt(id, prompt, c, app)
+		})
+
+		// update thread generation parameters
+		e.Router.PUT("/thread/params/:id", func(c echo.Context) error {
+			id := c.PathParam("id")
+			data := apis.RequestInfo(c).Data
+			return handlers.SaveThreadGenerationParams(id, data, c, app)
+		})
+
+		// update how the thread is fit into the model's context window
+		e.Router.PUT("/thread/context/:id", func(c echo.Context) error {
+			id := c.PathParam("id")
+			data := apis.RequestInfo(c).Data
+			strategy := handlers.FormValue(data, "context-strategy")
+			keep := handlers.FormValue(data, "context-keep")
+			return handlers.SaveThreadContextSettings(id, strategy, keep, c, app)
+		})
+
+		// choose the tools models can call in the thread
+		e.Router.PUT("/thread/tools/:id", func(c echo.Context) error {
+			id := c.PathParam("id")
+			data := apis.RequestInfo(c).Data
+			return handlers.SaveThreadTools(id, data, c, app)
+		})
+
 		// sort threads list
 		e.Router.GET("/sort/:method", func(c echo.Context) error {
 			method := c.PathParam("method")
@@ -147,6 +254,81 @@ func main() {
 			return handlers.UpdateApi(id, data, c, app)
 		})
 
+		// fallback chain of an API in the sidebar
+		e.Router.GET("/apis/fallbacks/:id", func(c echo.Context) error {
+			id := c.PathParam("id")
+			return handlers.GetApiFallbacks(id, c, app)
+		})
+
+		// add a fallback to the end of the chain
+		e.Router.POST("/apis/fallbacks/:id", func(c echo.Context) error {
+			id := c.PathParam("id")
+			data := apis.RequestInfo(c).Data
+			return handlers.AddApiFallback(id, data, c, app)
+		})
+
+		// remove a fallback from the chain
+		e.Router.DELETE("/apis/fallbacks/:id/:index", func(c echo.Context) error {
+			id := c.PathParam("id")
+			index := c.PathParam("index")
+			return handlers.RemoveApiFallback(id, index, c, app)
+		})
+
+		// models pulled to an Ollama server
+		e.Router.GET("/apis/ollama/:id", func(c echo.Context) error {
+			id := c.PathParam("id")
+			return handlers.GetOllamaModels(id, c, app)
+		})
+
+		// download a model to an Ollama server
+		e.Router.POST("/apis/ollama/:id/pull", func(c echo.Context) error {
+			id := c.PathParam("id")
+			data := apis.RequestInfo(c).Data
+			return handlers.PullOllamaModel(id, data, c, app)
+		})
+
+		// model names contain slashes and colons so they come as a query parameter
+		e.Router.DELETE("/apis/ollama/:id", func(c echo.Context) error {
+			id := c.PathParam("id")
+			model := c.QueryParam("model")
+			return handlers.DeleteOllamaModel(id, model, c, app)
+		})
+
+		// open the prompt library in the sidebar
+		e.Router.GET("/prompts/open", func(c echo.Context) error {
+			return handlers.OpenPromptEditor(c, app)
+		})
+
+		// create new prompt in the sidebar
+		e.Router.POST("/prompts/create", func(c echo.Context) error {
+			return handlers.CreatePrompt(c, app)
+		})
+
+		// delete a prompt in the sidebar
+		e.Router.DELETE("/prompts/:id", func(c echo.Context) error {
+			id := c.PathParam("id")
+			return handlers.DeletePrompt(id, c, app)
+		})
+
+		// update existing prompt in the sidebar
+		e.Router.PATCH("/prompts/update/:id", func(c echo.Context) error {
+			id := c.PathParam("id")
+			data := apis.RequestInfo(c).Data
+			return handlers.UpdatePrompt(id, data, c, app)
+		})
+
+		// list prompts matching a /command typed in the chat input
+		e.Router.POST("/prompts/suggest", func(c echo.Context) error {
+			data := apis.RequestInfo(c).Data
+			return handlers.SuggestPrompts(handlers.FormValue(data, "new-message"), c, app)
+		})
+
+		// inputs for the variables of the chosen prompt
+		e.Router.GET("/prompts/variables/:id", func(c echo.Context) error {
+			id := c.PathParam("id")
+			return handlers.OpenPromptVariables(id, c, app)
+		})
+
 		// open editor to create new tag
 		e.Router.GET("/thread/tag/:id", func(c echo.Context) error {
 			id := c.PathParam("id")
@@ -199,6 +381,31 @@ func main() {
 			return handlers.OpenConfig(c, app)
 		})
 
+		// update default system prompt used by threads without their own
+		e.Router.PUT("/config/system-prompt", func(c echo.Context) error {
+			data := apis.RequestInfo(c).Data
+			prompt := handlers.FormValue(data, "system-prompt")
+			return handlers.SaveDefaultSystemPrompt(prompt, c, app)
+		})
+
+		// update the model used to title new threads
+		e.Router.PUT("/config/titles", func(c echo.Context) error {
+			data := apis.RequestInfo(c).Data
+			return handlers.SaveTitleSettings(data, c, app)
+		})
+
+		// update the API and model messages are embedded with
+		e.Router.PUT("/config/embeddings", func(c echo.Context) error {
+			data := apis.RequestInfo(c).Data
+			return handlers.SaveEmbeddingSettings(data, c, app)
+		})
+
+		// update the directory the file tool can read
+		e.Router.PUT("/config/tools", func(c echo.Context) error {
+			data := apis.RequestInfo(c).Data
+			return handlers.SaveToolSettings(data, c, app)
+		})
+
 		// fetch model stats
 		e.Router.GET("/stats", func(c echo.Context) error {
 			return handlers.GetModelStats(c, app)
@@ -224,6 +431,9 @@ func main() {
 			return handlers.OpenChatSocket(&selectedModel, c, app)
 		})
 
+		// embed messages for searching by meaning, starting with the ones from before the last run
+		handlers.StartEmbeddingIndexer(app)
+
 		return nil
 	})
 
@@ -0,0 +1,229 @@
+// Package markdown renders model output to sanitized HTML, the same way while
+// streaming and when a thread is loaded.
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+)
+
+var converter = newConverter(true)
+
+// rendered again with every update while streaming, so code isn't highlighted until its block is finished
+var tailConverter = newConverter(false)
+
+func newConverter(highlight bool) goldmark.Markdown {
+	return goldmark.New(
+		goldmark.WithExtensions(extension.GFM),
+		goldmark.WithRendererOptions(
+			renderer.WithNodeRenderers(util.Prioritized(&codeRenderer{highlight: highlight}, 100)),
+		),
+	)
+}
+
+// model output is untrusted, raw HTML is already written as text but links
+// and anything else a renderer lets through are checked again
+var policy = newPolicy()
+
+func newPolicy() *bluemonday.Policy {
+	policy := bluemonday.UGCPolicy()
+	policy.AllowAttrs("class").Matching(regexp.MustCompile(`^[\w\- ]+$`)).OnElements("div", "span", "pre", "code", "button")
+	policy.AllowAttrs("data-lang").Matching(regexp.MustCompile(`^[\w\-+#.]*$`)).OnElements("div")
+	policy.AllowElements("button")
+	// task lists
+	policy.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	policy.AllowAttrs("checked", "disabled").OnElements("input")
+	// links open next to the chat instead of replacing it
+	policy.AddTargetBlankToFullyQualifiedLinks(true)
+	return policy
+}
+
+// Render turns markdown into sanitized HTML with highlighted code blocks.
+func Render(text string) string {
+	return render(converter, text)
+}
+
+// RenderTail renders the unfinished end of a streaming response like Render,
+// but leaves code plain since it is rendered again as more text arrives.
+func RenderTail(text string) string {
+	return render(tailConverter, text)
+}
+
+func render(converter goldmark.Markdown, text string) string {
+	if text == "" {
+		return ""
+	}
+
+	var buf bytes.Buffer
+	if err := converter.Convert([]byte(text), &buf); err != nil {
+		fmt.Printf("Failed to render markdown: %v\n", err)
+		return html.EscapeString(text)
+	}
+	return policy.Sanitize(buf.String())
+}
+
+// Split cuts streamed text after its last finished block, a blank line or the
+// end of a code fence. The finished part never changes as more text arrives,
+// so it is rendered once while the rest is rendered again as text arrives.
+func Split(text string) (finished string, rest string) {
+	end := 0
+	fence := ""
+	offset := 0
+	for {
+		newline := strings.IndexByte(text[offset:], '\n')
+		if newline < 0 {
+			break
+		}
+		line := text[offset : offset+newline]
+		offset += newline + 1
+
+		trimmed := strings.TrimLeft(line, " ")
+		marker := fenceMarker(trimmed)
+		switch {
+		case fence == "" && marker != "":
+			fence = marker
+		case fence != "" && strings.HasPrefix(trimmed, fence) && strings.TrimSpace(strings.TrimLeft(trimmed, fence[:1])) == "":
+			fence = ""
+			end = offset
+		case fence == "" && strings.TrimSpace(line) == "":
+			end = offset
+		}
+	}
+	return text[:end], text[end:]
+}
+
+// the ``` or ~~~ opening a code fence, empty if the line doesn't
+func fenceMarker(line string) string {
+	for _, char := range []string{"`", "~"} {
+		count := len(line) - len(strings.TrimLeft(line, char))
+		if count >= 3 {
+			return strings.Repeat(char, count)
+		}
+	}
+	return ""
+}
+
+// StreamUpdate splits a chunk added to text into the blocks it finished, rendered
+// to be appended to what is shown, and the unfinished rest of the text, left
+// for RenderTail. finished is false if the chunk didn't finish a block.
+func StreamUpdate(text string, chunk string) (blocks string, rest string, finished bool) {
+	finishedBefore, _ := Split(text)
+	finishedText, rest := Split(text + chunk)
+	if len(finishedText) > len(finishedBefore) {
+		return Render(finishedText[len(finishedBefore):]), rest, true
+	}
+	return "", rest, false
+}
+
+// renders code blocks with copy and download actions, and raw HTML as the text
+// it was written as
+type codeRenderer struct {
+	highlight bool
+}
+
+func (r *codeRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindFencedCodeBlock, r.renderFencedCodeBlock)
+	reg.Register(ast.KindCodeBlock, r.renderCodeBlock)
+	reg.Register(ast.KindHTMLBlock, r.renderHTMLBlock)
+	reg.Register(ast.KindRawHTML, r.renderRawHTML)
+}
+
+func (r *codeRenderer) renderFencedCodeBlock(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	block := node.(*ast.FencedCodeBlock)
+	writeCode(w, string(block.Language(source)), nodeLines(block, source), r.highlight)
+	return ast.WalkSkipChildren, nil
+}
+
+func (r *codeRenderer) renderCodeBlock(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	writeCode(w, "", nodeLines(node, source), r.highlight)
+	return ast.WalkSkipChildren, nil
+}
+
+func (r *codeRenderer) renderHTMLBlock(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	block := node.(*ast.HTMLBlock)
+	text := nodeLines(block, source)
+	if block.HasClosure() {
+		text += string(block.ClosureLine.Value(source))
+	}
+	w.WriteString("<p>" + html.EscapeString(strings.TrimRight(text, "\n")) + "</p>\n")
+	return ast.WalkSkipChildren, nil
+}
+
+func (r *codeRenderer) renderRawHTML(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	segments := node.(*ast.RawHTML).Segments
+	for i := 0; i < segments.Len(); i++ {
+		segment := segments.At(i)
+		w.WriteString(html.EscapeString(string(segment.Value(source))))
+	}
+	return ast.WalkSkipChildren, nil
+}
+
+func nodeLines(node ast.Node, source []byte) string {
+	var text strings.Builder
+	lines := node.Lines()
+	for i := 0; i < lines.Len(); i++ {
+		line := lines.At(i)
+		text.Write(line.Value(source))
+	}
+	return text.String()
+}
+
+var codeFormatter = chromahtml.New(chromahtml.WithClasses(true), chromahtml.PreventSurroundingPre(true))
+
+// the language is kept on the block so downloads get a fitting file extension
+func writeCode(w util.BufWriter, language string, code string, highlight bool) {
+	language = strings.ToLower(strings.TrimSpace(language))
+
+	var highlighted bytes.Buffer
+	if highlight {
+		lexer := lexers.Get(language)
+		if lexer == nil {
+			lexer = lexers.Fallback
+		}
+		lexer = chroma.Coalesce(lexer)
+
+		iterator, err := lexer.Tokenise(nil, code)
+		if err == nil {
+			err = codeFormatter.Format(&highlighted, styles.Fallback, iterator)
+		}
+		if err != nil {
+			highlighted.Reset()
+		}
+	}
+	if highlighted.Len() == 0 {
+		highlighted.WriteString(html.EscapeString(code))
+	}
+
+	w.WriteString(`<div class="code-block" data-lang="` + html.EscapeString(language) + `">`)
+	w.WriteString(`<div class="code-block-header"><span class="code-block-lang">` + html.EscapeString(language) + `</span>`)
+	w.WriteString(`<button class="code-copy">copy</button><button class="code-download">download</button></div>`)
+	w.WriteString(`<pre class="chroma"><code>`)
+	w.Write(highlighted.Bytes())
+	w.WriteString("</code></pre></div>\n")
+}
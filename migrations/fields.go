@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"encoding/json"
+
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models/schema"
+)
+
+// add the JSON encoded schema fields to an existing collection
+func addFields(dao *daos.Dao, collectionName string, fieldsJson string) error {
+	collection, err := dao.FindCollectionByNameOrId(collectionName)
+	if err != nil {
+		return err
+	}
+
+	fields := []*schema.SchemaField{}
+	if err := json.Unmarshal([]byte(fieldsJson), &fields); err != nil {
+		return err
+	}
+
+	for _, field := range fields {
+		collection.Schema.AddField(field)
+	}
+
+	return dao.SaveCollection(collection)
+}
+
+// remove schema fields from an existing collection by field id
+func removeFields(dao *daos.Dao, collectionName string, fieldIds ...string) error {
+	collection, err := dao.FindCollectionByNameOrId(collectionName)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range fieldIds {
+		collection.Schema.RemoveField(id)
+	}
+
+	return dao.SaveCollection(collection)
+}
@@ -0,0 +1,58 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	m "github.com/pocketbase/pocketbase/migrations"
+)
+
+// system prompts are stored per thread, with a global default in settings
+func init() {
+	m.Register(func(db dbx.Builder) error {
+		dao := daos.New(db)
+
+		if err := addFields(dao, "chat_meta", `[
+			{
+				"system": false,
+				"id": "s7pmtq2d",
+				"name": "system_prompt",
+				"type": "text",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"min": null,
+					"max": null,
+					"pattern": ""
+				}
+			}
+		]`); err != nil {
+			return err
+		}
+
+		return addFields(dao, "settings", `[
+			{
+				"system": false,
+				"id": "d4fsyspr",
+				"name": "system_prompt",
+				"type": "text",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"min": null,
+					"max": null,
+					"pattern": ""
+				}
+			}
+		]`)
+	}, func(db dbx.Builder) error {
+		dao := daos.New(db)
+
+		if err := removeFields(dao, "chat_meta", "s7pmtq2d"); err != nil {
+			return err
+		}
+
+		return removeFields(dao, "settings", "d4fsyspr")
+	})
+}
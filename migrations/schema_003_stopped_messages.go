@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	m "github.com/pocketbase/pocketbase/migrations"
+)
+
+// marks model messages that were stopped before the stream finished
+func init() {
+	m.Register(func(db dbx.Builder) error {
+		return addFields(daos.New(db), "chat", `[
+			{
+				"system": false,
+				"id": "t0ppdmsg",
+				"name": "stopped",
+				"type": "bool",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {}
+			}
+		]`)
+	}, func(db dbx.Builder) error {
+		return removeFields(daos.New(db), "chat", "t0ppdmsg")
+	})
+}
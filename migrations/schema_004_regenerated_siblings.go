@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	m "github.com/pocketbase/pocketbase/migrations"
+)
+
+// regenerated responses are kept as siblings, only the active sibling is used as context
+func init() {
+	m.Register(func(db dbx.Builder) error {
+		return addFields(daos.New(db), "chat", `[
+			{
+				"system": false,
+				"id": "inactv4s",
+				"name": "inactive",
+				"type": "bool",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {}
+			}
+		]`)
+	}, func(db dbx.Builder) error {
+		return removeFields(daos.New(db), "chat", "inactv4s")
+	})
+}
@@ -0,0 +1,58 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	m "github.com/pocketbase/pocketbase/migrations"
+)
+
+// chat messages form a tree through parent_id, existing threads become a linear chain
+func init() {
+	m.Register(func(db dbx.Builder) error {
+		if err := addFields(daos.New(db), "chat", `[
+			{
+				"system": false,
+				"id": "prntid5t",
+				"name": "parent_id",
+				"type": "text",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"min": null,
+					"max": null,
+					"pattern": ""
+				}
+			}
+		]`); err != nil {
+			return err
+		}
+
+		type flatMessage struct {
+			Id       string `db:"id"`
+			ThreadId string `db:"thread_id"`
+		}
+
+		var messages []flatMessage
+		err := db.Select("id", "thread_id").
+			From("chat").
+			OrderBy("created ASC", "rowid ASC").
+			All(&messages)
+		if err != nil {
+			return err
+		}
+
+		lastMessages := make(map[string]string)
+		for _, message := range messages {
+			_, err := db.Update("chat", dbx.Params{"parent_id": lastMessages[message.ThreadId]}, dbx.HashExp{"id": message.Id}).Execute()
+			if err != nil {
+				return err
+			}
+			lastMessages[message.ThreadId] = message.Id
+		}
+
+		return nil
+	}, func(db dbx.Builder) error {
+		return removeFields(daos.New(db), "chat", "prntid5t")
+	})
+}
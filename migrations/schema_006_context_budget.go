@@ -0,0 +1,101 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	m "github.com/pocketbase/pocketbase/migrations"
+)
+
+// threads choose how long histories are fit into the model's context window,
+// APIs can set a context window for models their /models endpoint doesn't describe
+func init() {
+	m.Register(func(db dbx.Builder) error {
+		dao := daos.New(db)
+
+		if err := addFields(dao, "chat_meta", `[
+			{
+				"system": false,
+				"id": "ctxstr6g",
+				"name": "context_strategy",
+				"type": "text",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"min": null,
+					"max": null,
+					"pattern": ""
+				}
+			},
+			{
+				"system": false,
+				"id": "ctxkep6n",
+				"name": "context_keep",
+				"type": "number",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"min": null,
+					"max": null,
+					"noDecimal": true
+				}
+			},
+			{
+				"system": false,
+				"id": "ctxsum6t",
+				"name": "context_summary",
+				"type": "text",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"min": null,
+					"max": null,
+					"pattern": ""
+				}
+			},
+			{
+				"system": false,
+				"id": "ctxsof6t",
+				"name": "context_summary_of",
+				"type": "text",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"min": null,
+					"max": null,
+					"pattern": ""
+				}
+			}
+		]`); err != nil {
+			return err
+		}
+
+		return addFields(dao, "apis", `[
+			{
+				"system": false,
+				"id": "ctxwin6a",
+				"name": "context_window",
+				"type": "number",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"min": null,
+					"max": null,
+					"noDecimal": true
+				}
+			}
+		]`)
+	}, func(db dbx.Builder) error {
+		dao := daos.New(db)
+
+		if err := removeFields(dao, "chat_meta", "ctxstr6g", "ctxkep6n", "ctxsum6t", "ctxsof6t"); err != nil {
+			return err
+		}
+
+		return removeFields(dao, "apis", "ctxwin6a")
+	})
+}
@@ -0,0 +1,76 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	m "github.com/pocketbase/pocketbase/migrations"
+)
+
+// sampling parameters set per thread with defaults per API,
+// model messages keep the parameters they were generated with
+func init() {
+	m.Register(func(db dbx.Builder) error {
+		dao := daos.New(db)
+
+		if err := addFields(dao, "chat_meta", `[
+			{
+				"system": false,
+				"id": "gnprm7cm",
+				"name": "generation_params",
+				"type": "json",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"maxSize": 2000000
+				}
+			}
+		]`); err != nil {
+			return err
+		}
+
+		if err := addFields(dao, "apis", `[
+			{
+				"system": false,
+				"id": "gnprm7ap",
+				"name": "generation_params",
+				"type": "json",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"maxSize": 2000000
+				}
+			}
+		]`); err != nil {
+			return err
+		}
+
+		return addFields(dao, "chat", `[
+			{
+				"system": false,
+				"id": "gnprm7ch",
+				"name": "generation_params",
+				"type": "json",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"maxSize": 2000000
+				}
+			}
+		]`)
+	}, func(db dbx.Builder) error {
+		dao := daos.New(db)
+
+		if err := removeFields(dao, "chat_meta", "gnprm7cm"); err != nil {
+			return err
+		}
+
+		if err := removeFields(dao, "apis", "gnprm7ap"); err != nil {
+			return err
+		}
+
+		return removeFields(dao, "chat", "gnprm7ch")
+	})
+}
@@ -0,0 +1,93 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	m "github.com/pocketbase/pocketbase/migrations"
+)
+
+// token usage of each model message, either reported by the API or estimated locally
+func init() {
+	m.Register(func(db dbx.Builder) error {
+		dao := daos.New(db)
+
+		if err := addFields(dao, "chat", `[
+			{
+				"system": false,
+				"id": "prmtok8c",
+				"name": "prompt_tokens",
+				"type": "number",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"min": null,
+					"max": null,
+					"noDecimal": true
+				}
+			},
+			{
+				"system": false,
+				"id": "cmptok8c",
+				"name": "completion_tokens",
+				"type": "number",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"min": null,
+					"max": null,
+					"noDecimal": true
+				}
+			},
+			{
+				"system": false,
+				"id": "tottok8c",
+				"name": "total_tokens",
+				"type": "number",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"min": null,
+					"max": null,
+					"noDecimal": true
+				}
+			},
+			{
+				"system": false,
+				"id": "estusg8c",
+				"name": "usage_estimated",
+				"type": "bool",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {}
+			}
+		]`); err != nil {
+			return err
+		}
+
+		// some OpenAI compatible servers reject the stream_options used to ask for usage
+		return addFields(dao, "apis", `[
+			{
+				"system": false,
+				"id": "skpusg8a",
+				"name": "skip_stream_usage",
+				"type": "bool",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {}
+			}
+		]`)
+	}, func(db dbx.Builder) error {
+		dao := daos.New(db)
+
+		if err := removeFields(dao, "chat", "prmtok8c", "cmptok8c", "tottok8c", "estusg8c"); err != nil {
+			return err
+		}
+
+		return removeFields(dao, "apis", "skpusg8a")
+	})
+}
@@ -0,0 +1,58 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	m "github.com/pocketbase/pocketbase/migrations"
+)
+
+// prompts sent to several models at once, the answers are siblings under the human message
+func init() {
+	m.Register(func(db dbx.Builder) error {
+		dao := daos.New(db)
+
+		// "open" until an answer is kept, "all" when every answer stays in the thread
+		if err := addFields(dao, "chat", `[
+			{
+				"system": false,
+				"id": "cmpst9ch",
+				"name": "compare",
+				"type": "text",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"min": null,
+					"max": null,
+					"pattern": ""
+				}
+			}
+		]`); err != nil {
+			return err
+		}
+
+		// API and model pairs a compare prompt is sent to
+		return addFields(dao, "users", `[
+			{
+				"system": false,
+				"id": "cmpmd9us",
+				"name": "compare_models",
+				"type": "json",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"maxSize": 2000000
+				}
+			}
+		]`)
+	}, func(db dbx.Builder) error {
+		dao := daos.New(db)
+
+		if err := removeFields(dao, "chat", "cmpst9ch"); err != nil {
+			return err
+		}
+
+		return removeFields(dao, "users", "cmpmd9us")
+	})
+}
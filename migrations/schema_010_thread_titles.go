@@ -0,0 +1,80 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	m "github.com/pocketbase/pocketbase/migrations"
+)
+
+// titles written by a model after the first exchange of a thread
+func init() {
+	m.Register(func(db dbx.Builder) error {
+		dao := daos.New(db)
+
+		// an empty title API uses the API and model that answered
+		if err := addFields(dao, "settings", `[
+			{
+				"system": false,
+				"id": "autttl10",
+				"name": "auto_titles",
+				"type": "bool",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {}
+			},
+			{
+				"system": false,
+				"id": "ttlapi10",
+				"name": "title_api",
+				"type": "text",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"min": null,
+					"max": null,
+					"pattern": ""
+				}
+			},
+			{
+				"system": false,
+				"id": "ttlmdl10",
+				"name": "title_model",
+				"type": "text",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"min": null,
+					"max": null,
+					"pattern": ""
+				}
+			}
+		]`); err != nil {
+			return err
+		}
+
+		// titles set by hand are never replaced
+		return addFields(dao, "chat_meta", `[
+			{
+				"system": false,
+				"id": "ttlusr10",
+				"name": "title_set_by_user",
+				"type": "bool",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {}
+			}
+		]`)
+	}, func(db dbx.Builder) error {
+		dao := daos.New(db)
+
+		if err := removeFields(dao, "settings", "autttl10", "ttlapi10", "ttlmdl10"); err != nil {
+			return err
+		}
+
+		return removeFields(dao, "chat_meta", "ttlusr10")
+	})
+}
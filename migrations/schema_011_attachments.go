@@ -0,0 +1,60 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	m "github.com/pocketbase/pocketbase/migrations"
+)
+
+// files attached to human messages, with the names, types and text extracted when they were uploaded
+// attachments are uploaded to a draft message that is filled in when the message is sent
+func init() {
+	m.Register(func(db dbx.Builder) error {
+		dao := daos.New(db)
+
+		return addFields(dao, "chat", `[
+			{
+				"system": false,
+				"id": "atchfl11",
+				"name": "attachments",
+				"type": "file",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"mimeTypes": [],
+					"thumbs": null,
+					"maxSelect": 10,
+					"maxSize": 20971520,
+					"protected": false
+				}
+			},
+			{
+				"system": false,
+				"id": "atchmt11",
+				"name": "attachment_meta",
+				"type": "json",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"maxSize": 20000000
+				}
+			},
+			{
+				"system": false,
+				"id": "draft011",
+				"name": "draft",
+				"type": "bool",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {}
+			}
+		]`)
+	}, func(db dbx.Builder) error {
+		dao := daos.New(db)
+
+		return removeFields(dao, "chat", "atchfl11", "atchmt11", "draft011")
+	})
+}
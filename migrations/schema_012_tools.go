@@ -0,0 +1,105 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	m "github.com/pocketbase/pocketbase/migrations"
+)
+
+// tools a model can call while answering
+// model messages keep the calls they made, each call and its result is a tool message after them
+func init() {
+	m.Register(func(db dbx.Builder) error {
+		dao := daos.New(db)
+
+		if err := addFields(dao, "chat", `[
+			{
+				"system": false,
+				"id": "tlcals12",
+				"name": "tool_calls",
+				"type": "json",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"maxSize": 2000000
+				}
+			},
+			{
+				"system": false,
+				"id": "tlcall12",
+				"name": "tool_call",
+				"type": "json",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"maxSize": 2000000
+				}
+			},
+			{
+				"system": false,
+				"id": "tlstat12",
+				"name": "tool_status",
+				"type": "text",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"min": null,
+					"max": null,
+					"pattern": ""
+				}
+			}
+		]`); err != nil {
+			return err
+		}
+
+		// names of the tools enabled in a thread
+		if err := addFields(dao, "chat_meta", `[
+			{
+				"system": false,
+				"id": "tlsthr12",
+				"name": "tools",
+				"type": "json",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"maxSize": 2000000
+				}
+			}
+		]`); err != nil {
+			return err
+		}
+
+		// the only directory the read_file tool can read from
+		return addFields(dao, "settings", `[
+			{
+				"system": false,
+				"id": "tlsbox12",
+				"name": "tool_sandbox_dir",
+				"type": "text",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"min": null,
+					"max": null,
+					"pattern": ""
+				}
+			}
+		]`)
+	}, func(db dbx.Builder) error {
+		dao := daos.New(db)
+
+		if err := removeFields(dao, "chat", "tlcals12", "tlcall12", "tlstat12"); err != nil {
+			return err
+		}
+		if err := removeFields(dao, "chat_meta", "tlsthr12"); err != nil {
+			return err
+		}
+
+		return removeFields(dao, "settings", "tlsbox12")
+	})
+}
@@ -0,0 +1,123 @@
+package migrations
+
+import (
+	"encoding/json"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	m "github.com/pocketbase/pocketbase/migrations"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// saved prompt templates with {{variables}}, inserted with /name in the chat input
+func init() {
+	m.Register(func(db dbx.Builder) error {
+		dao := daos.New(db)
+
+		collection := &models.Collection{}
+		if err := json.Unmarshal([]byte(`{
+			"id": "prmptlibrary013",
+			"name": "prompts",
+			"type": "base",
+			"system": false,
+			"schema": [
+				{
+					"system": false,
+					"id": "prmtnm13",
+					"name": "name",
+					"type": "text",
+					"required": false,
+					"presentable": true,
+					"unique": false,
+					"options": {
+						"min": null,
+						"max": 64,
+						"pattern": "^[A-Za-z0-9_-]*$"
+					}
+				},
+				{
+					"system": false,
+					"id": "prmttp13",
+					"name": "template",
+					"type": "text",
+					"required": false,
+					"presentable": false,
+					"unique": false,
+					"options": {
+						"min": null,
+						"max": null,
+						"pattern": ""
+					}
+				}
+			],
+			"indexes": [],
+			"listRule": null,
+			"viewRule": null,
+			"createRule": null,
+			"updateRule": null,
+			"deleteRule": null,
+			"options": {}
+		}`), collection); err != nil {
+			return err
+		}
+		if err := dao.SaveCollection(collection); err != nil {
+			return err
+		}
+
+		// the template a message was expanded from, kept even if the template changes later
+		return addFields(dao, "chat", `[
+			{
+				"system": false,
+				"id": "prmtid13",
+				"name": "prompt_id",
+				"type": "text",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"min": null,
+					"max": null,
+					"pattern": ""
+				}
+			},
+			{
+				"system": false,
+				"id": "prmtnc13",
+				"name": "prompt_name",
+				"type": "text",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"min": null,
+					"max": null,
+					"pattern": ""
+				}
+			},
+			{
+				"system": false,
+				"id": "prmtvr13",
+				"name": "prompt_variables",
+				"type": "json",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"maxSize": 2000000
+				}
+			}
+		]`)
+	}, func(db dbx.Builder) error {
+		dao := daos.New(db)
+
+		if err := removeFields(dao, "chat", "prmtid13", "prmtnc13", "prmtvr13"); err != nil {
+			return err
+		}
+
+		collection, err := dao.FindCollectionByNameOrId("prompts")
+		if err != nil {
+			return err
+		}
+		return dao.DeleteCollection(collection)
+	})
+}
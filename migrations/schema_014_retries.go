@@ -0,0 +1,56 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	m "github.com/pocketbase/pocketbase/migrations"
+)
+
+// retries of failed requests and fallback APIs tried when an API keeps failing
+func init() {
+	m.Register(func(db dbx.Builder) error {
+		dao := daos.New(db)
+
+		// APIs and models tried in order once this API gives up
+		if err := addFields(dao, "apis", `[
+			{
+				"system": false,
+				"id": "fallbk14",
+				"name": "fallbacks",
+				"type": "json",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"maxSize": 2000000
+				}
+			}
+		]`); err != nil {
+			return err
+		}
+
+		// every API and model a response was requested from, the last one answered
+		return addFields(dao, "chat", `[
+			{
+				"system": false,
+				"id": "route014",
+				"name": "route",
+				"type": "json",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"maxSize": 2000000
+				}
+			}
+		]`)
+	}, func(db dbx.Builder) error {
+		dao := daos.New(db)
+
+		if err := removeFields(dao, "apis", "fallbk14"); err != nil {
+			return err
+		}
+
+		return removeFields(dao, "chat", "route014")
+	})
+}
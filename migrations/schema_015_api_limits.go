@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	m "github.com/pocketbase/pocketbase/migrations"
+)
+
+// requests an API serves at once, so a local server isn't flooded by parallel threads
+func init() {
+	m.Register(func(db dbx.Builder) error {
+		dao := daos.New(db)
+
+		// 0 leaves the API unlimited
+		return addFields(dao, "apis", `[
+			{
+				"system": false,
+				"id": "maxcon15",
+				"name": "max_concurrent",
+				"type": "number",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"min": null,
+					"max": null,
+					"noDecimal": true
+				}
+			}
+		]`)
+	}, func(db dbx.Builder) error {
+		dao := daos.New(db)
+
+		return removeFields(dao, "apis", "maxcon15")
+	})
+}
@@ -0,0 +1,77 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	m "github.com/pocketbase/pocketbase/migrations"
+)
+
+// timings of model responses, the request start goes in the existing timestamp field
+func init() {
+	m.Register(func(db dbx.Builder) error {
+		dao := daos.New(db)
+
+		return addFields(dao, "chat", `[
+			{
+				"system": false,
+				"id": "ttftms16",
+				"name": "ttft_ms",
+				"type": "number",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"min": null,
+					"max": null,
+					"noDecimal": true
+				}
+			},
+			{
+				"system": false,
+				"id": "durtms16",
+				"name": "duration_ms",
+				"type": "number",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"min": null,
+					"max": null,
+					"noDecimal": true
+				}
+			},
+			{
+				"system": false,
+				"id": "tokpsc16",
+				"name": "tokens_per_second",
+				"type": "number",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"min": null,
+					"max": null,
+					"noDecimal": false
+				}
+			},
+			{
+				"system": false,
+				"id": "finrsn16",
+				"name": "finish_reason",
+				"type": "text",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"min": null,
+					"max": null,
+					"pattern": ""
+				}
+			}
+		]`)
+	}, func(db dbx.Builder) error {
+		dao := daos.New(db)
+
+		return removeFields(dao, "chat", "ttftms16", "durtms16", "tokpsc16", "finrsn16")
+	})
+}
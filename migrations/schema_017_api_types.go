@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	m "github.com/pocketbase/pocketbase/migrations"
+)
+
+// the backend an API uses, APIs from before are OpenAI compatible
+func init() {
+	m.Register(func(db dbx.Builder) error {
+		dao := daos.New(db)
+
+		if err := addFields(dao, "apis", `[
+			{
+				"system": false,
+				"id": "apityp17",
+				"name": "type",
+				"type": "text",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"min": null,
+					"max": null,
+					"pattern": ""
+				}
+			}
+		]`); err != nil {
+			return err
+		}
+
+		_, err := db.Update("apis", dbx.Params{"type": "openai"}, dbx.HashExp{"type": ""}).Execute()
+		return err
+	}, func(db dbx.Builder) error {
+		dao := daos.New(db)
+
+		return removeFields(dao, "apis", "apityp17")
+	})
+}
@@ -0,0 +1,50 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	m "github.com/pocketbase/pocketbase/migrations"
+)
+
+// APIs with a prompt format send threads to the text completion endpoint,
+// the template is used by the custom format
+func init() {
+	m.Register(func(db dbx.Builder) error {
+		dao := daos.New(db)
+
+		return addFields(dao, "apis", `[
+			{
+				"system": false,
+				"id": "prmfmt18",
+				"name": "prompt_format",
+				"type": "text",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"min": null,
+					"max": null,
+					"pattern": ""
+				}
+			},
+			{
+				"system": false,
+				"id": "prmtpl18",
+				"name": "prompt_template",
+				"type": "text",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"min": null,
+					"max": null,
+					"pattern": ""
+				}
+			}
+		]`)
+	}, func(db dbx.Builder) error {
+		dao := daos.New(db)
+
+		return removeFields(dao, "apis", "prmfmt18", "prmtpl18")
+	})
+}
@@ -0,0 +1,187 @@
+package migrations
+
+import (
+	"encoding/json"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	m "github.com/pocketbase/pocketbase/migrations"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// embedded passages of chat messages, searched by meaning
+func init() {
+	m.Register(func(db dbx.Builder) error {
+		dao := daos.New(db)
+
+		// model is the embedding API and model, passages of any other one are dropped
+		// message_updated is the updated time of the message when it was embedded
+		// vector is base64 of little endian float32s, scaled to unit length
+		collection := &models.Collection{}
+		if err := json.Unmarshal([]byte(`{
+			"id": "msgembedding020",
+			"name": "message_embeddings",
+			"type": "base",
+			"system": false,
+			"schema": [
+				{
+					"system": false,
+					"id": "embmsg20",
+					"name": "message_id",
+					"type": "text",
+					"required": false,
+					"presentable": false,
+					"unique": false,
+					"options": {
+						"min": null,
+						"max": null,
+						"pattern": ""
+					}
+				},
+				{
+					"system": false,
+					"id": "embthr20",
+					"name": "thread_id",
+					"type": "text",
+					"required": false,
+					"presentable": false,
+					"unique": false,
+					"options": {
+						"min": null,
+						"max": null,
+						"pattern": ""
+					}
+				},
+				{
+					"system": false,
+					"id": "embpsg20",
+					"name": "passage",
+					"type": "number",
+					"required": false,
+					"presentable": false,
+					"unique": false,
+					"options": {
+						"min": null,
+						"max": null,
+						"noDecimal": true
+					}
+				},
+				{
+					"system": false,
+					"id": "embtxt20",
+					"name": "text",
+					"type": "text",
+					"required": false,
+					"presentable": false,
+					"unique": false,
+					"options": {
+						"min": null,
+						"max": null,
+						"pattern": ""
+					}
+				},
+				{
+					"system": false,
+					"id": "embmdl20",
+					"name": "model",
+					"type": "text",
+					"required": false,
+					"presentable": false,
+					"unique": false,
+					"options": {
+						"min": null,
+						"max": null,
+						"pattern": ""
+					}
+				},
+				{
+					"system": false,
+					"id": "embupd20",
+					"name": "message_updated",
+					"type": "text",
+					"required": false,
+					"presentable": false,
+					"unique": false,
+					"options": {
+						"min": null,
+						"max": null,
+						"pattern": ""
+					}
+				},
+				{
+					"system": false,
+					"id": "embvec20",
+					"name": "vector",
+					"type": "text",
+					"required": false,
+					"presentable": false,
+					"unique": false,
+					"options": {
+						"min": null,
+						"max": null,
+						"pattern": ""
+					}
+				}
+			],
+			"indexes": [
+				"CREATE INDEX idx_message_embeddings_message ON message_embeddings (message_id)",
+				"CREATE INDEX idx_message_embeddings_model ON message_embeddings (model)"
+			],
+			"listRule": null,
+			"viewRule": null,
+			"createRule": null,
+			"updateRule": null,
+			"deleteRule": null,
+			"options": {}
+		}`), collection); err != nil {
+			return err
+		}
+		if err := dao.SaveCollection(collection); err != nil {
+			return err
+		}
+
+		// nothing is embedded until an API and model are picked
+		return addFields(dao, "settings", `[
+			{
+				"system": false,
+				"id": "embapi20",
+				"name": "embedding_api",
+				"type": "text",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"min": null,
+					"max": null,
+					"pattern": ""
+				}
+			},
+			{
+				"system": false,
+				"id": "embmod20",
+				"name": "embedding_model",
+				"type": "text",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {
+					"min": null,
+					"max": null,
+					"pattern": ""
+				}
+			}
+		]`)
+	}, func(db dbx.Builder) error {
+		dao := daos.New(db)
+
+		if err := removeFields(dao, "settings", "embapi20", "embmod20"); err != nil {
+			return err
+		}
+
+		collection, err := dao.FindCollectionByNameOrId("message_embeddings")
+		if err != nil {
+			return err
+		}
+		return dao.DeleteCollection(collection)
+	})
+}
@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+	m "github.com/pocketbase/pocketbase/migrations"
+)
+
+// full-text index of message text and thread titles, kept up to date by record hooks
+func init() {
+	m.Register(func(db dbx.Builder) error {
+		// rows share the rowid of the message they index, titles the negated rowid of their thread
+		// titles have an empty message_id, messages of deleted threads are left out by joining on chat
+		if _, err := db.NewQuery(`CREATE VIRTUAL TABLE message_search USING fts5(
+			body,
+			message_id UNINDEXED,
+			thread_id UNINDEXED,
+			tokenize = 'porter unicode61'
+		)`).Execute(); err != nil {
+			return err
+		}
+
+		if _, err := db.NewQuery(`INSERT INTO message_search (rowid, body, message_id, thread_id)
+			SELECT rowid, message, id, thread_id FROM chat
+			WHERE draft = FALSE AND sender IN ('human', 'model') AND TRIM(message) != ''`).Execute(); err != nil {
+			return err
+		}
+
+		// new threads are titled with their id until they are named
+		_, err := db.NewQuery(`INSERT INTO message_search (rowid, body, message_id, thread_id)
+			SELECT -rowid, thread_title, '', id FROM chat_meta
+			WHERE TRIM(thread_title) != '' AND thread_title != id`).Execute()
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.NewQuery("DROP TABLE IF EXISTS message_search").Execute()
+		return err
+	})
+}
@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"slices"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	m "github.com/pocketbase/pocketbase/migrations"
+)
+
+// searches walk the shown branch of every thread, which needs the children of a message
+// and the siblings of a fork without scanning the whole chat table
+var messageTreeIndexes = []string{
+	"CREATE INDEX idx_chat_thread_parent ON chat (thread_id, parent_id)",
+	"CREATE INDEX idx_chat_parent ON chat (parent_id)",
+}
+
+func init() {
+	m.Register(func(db dbx.Builder) error {
+		dao := daos.New(db)
+
+		collection, err := dao.FindCollectionByNameOrId("chat")
+		if err != nil {
+			return err
+		}
+		collection.Indexes = append(collection.Indexes, messageTreeIndexes...)
+		return dao.SaveCollection(collection)
+	}, func(db dbx.Builder) error {
+		dao := daos.New(db)
+
+		collection, err := dao.FindCollectionByNameOrId("chat")
+		if err != nil {
+			return err
+		}
+		collection.Indexes = slices.DeleteFunc(collection.Indexes, func(index string) bool {
+			return slices.Contains(messageTreeIndexes, index)
+		})
+		return dao.SaveCollection(collection)
+	})
+}
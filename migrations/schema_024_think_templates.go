@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	m "github.com/pocketbase/pocketbase/migrations"
+)
+
+// set on APIs whose chat template starts the model's turn inside a <think> block
+func init() {
+	m.Register(func(db dbx.Builder) error {
+		return addFields(daos.New(db), "apis", `[
+			{
+				"system": false,
+				"id": "opnthk24",
+				"name": "template_opens_think",
+				"type": "bool",
+				"required": false,
+				"presentable": false,
+				"unique": false,
+				"options": {}
+			}
+		]`)
+	}, func(db dbx.Builder) error {
+		return removeFields(daos.New(db), "apis", "opnthk24")
+	})
+}